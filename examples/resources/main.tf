@@ -28,6 +28,22 @@ resource "gsolaceclustermgr_broker" "ocs-test" {
   #custom_router_name = "ocsrouter1"
   #event_broker_version = "10.8.1.152-7"
   #max_spool_usage = 40
+
+  # create a private endpoint instead of the default endpoint
+  #service_connection_endpoint {
+  #  name        = "private"
+  #  access_type = "PRIVATE"
+  #  ports = {
+  #    serviceSmfTlsListenPort        = 55443
+  #    serviceManagementTlsListenPort = 943
+  #  }
+  #}
+
+  # override the provider polling_timeout_duration for this broker
+  #timeouts {
+  #  create = "60m"
+  #  delete = "20m"
+  #}
 }
 
 output "ocs-test_id" {
@@ -0,0 +1,68 @@
+package fakeserver
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redundancy is reported down while a switchover is in progress
+const switchoverDuration = 3 * time.Second
+
+// handleBrokerState handles GET .../eventBrokerServices/{sid}/brokerState
+func (svr *Fakeserver) handleBrokerState(w http.ResponseWriter, sInfo *ServiceInfo) {
+	state := map[string]interface{}{
+		"id":                 sInfo.ID,
+		"isHighAvailability": isHighAvailability(sInfo),
+		"type":               "brokerState",
+	}
+	if isHighAvailability(sInfo) {
+		redundancy := "UP"
+		if time.Since(sInfo.SwitchoverStarted) < switchoverDuration {
+			redundancy = "DOWN"
+		}
+		state["redundancy"] = map[string]interface{}{
+			"activeNode": activeNode(sInfo),
+			"configSync": redundancy,
+			"redundancy": redundancy,
+		}
+	}
+	svr.writeData(w, 200, state)
+}
+
+// handleSwitchover handles POST .../eventBrokerServices/{sid}/switchover
+func (svr *Fakeserver) handleSwitchover(w http.ResponseWriter, sInfo *ServiceInfo) {
+	if !isHighAvailability(sInfo) {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Event broker service %s is not highly available\",\"errorId\":\"42\"}", sInfo.ID), http.StatusBadRequest)
+		return
+	}
+	if time.Since(sInfo.SwitchoverStarted) < switchoverDuration {
+		http.Error(w, fmt.Sprintf("{\"message\":\"A switchover of event broker service %s is in progress\",\"errorId\":\"42\"}", sInfo.ID), http.StatusConflict)
+		return
+	}
+	// the backup takes over right away, the redundancy is down until the operation completes
+	if activeNode(sInfo) == "PRIMARY" {
+		sInfo.ActiveNode = "BACKUP"
+	} else {
+		sInfo.ActiveNode = "PRIMARY"
+	}
+	sInfo.SwitchoverStarted = time.Now()
+	svr.objects[sInfo.ID] = *sInfo
+	if svr.debug {
+		log.Printf("fakeserver: switchover of service %s to %s", sInfo.ID, sInfo.ActiveNode)
+	}
+	svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, sInfo.ID, "switchoverBroker"))
+}
+
+func isHighAvailability(sInfo *ServiceInfo) bool {
+	return strings.HasSuffix(sInfo.ServiceClassId, "_HIGHAVAILABILITY")
+}
+
+func activeNode(sInfo *ServiceInfo) string {
+	if sInfo.ActiveNode == "" {
+		return "PRIMARY"
+	}
+	return sInfo.ActiveNode
+}
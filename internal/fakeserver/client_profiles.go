@@ -0,0 +1,214 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// clientProfileDefaults are the values the server fills in for attributes not given on creation
+var clientProfileDefaults = map[string]interface{}{
+	"allowBridgeConnectionsEnabled":                          false,
+	"allowGuaranteedEndpointCreateEnabled":                   false,
+	"allowGuaranteedMsgReceiveEnabled":                       false,
+	"allowGuaranteedMsgSendEnabled":                          false,
+	"allowSharedSubscriptionsEnabled":                        false,
+	"allowTransactedSessionsEnabled":                         true,
+	"apiQueueManagementCopyFromOnCreateTemplateName":         "",
+	"apiTopicEndpointManagementCopyFromOnCreateTemplateName": "",
+	"compressionEnabled":                                     true,
+	"elidingDelay":                                           0,
+	"elidingEnabled":                                         false,
+	"elidingMaxTopicCount":                                   256,
+	"eventClientProvisionedEndpointSpoolUsageThreshold": map[string]interface{}{
+		"clearPercent": 60,
+		"setPercent":   80,
+	},
+	"maxConnectionCountPerClientUsername":             100,
+	"maxEgressFlowCount":                              100,
+	"maxEndpointCountPerClientUsername":               100,
+	"maxIngressFlowCount":                             100,
+	"maxSubscriptionCount":                            5000,
+	"maxTransactedSessionCount":                       10,
+	"maxTransactionCount":                             10,
+	"queueControl1MaxDepth":                           20000,
+	"queueControl1MinMsgBurst":                        4,
+	"queueDirect1MaxDepth":                            20000,
+	"queueDirect1MinMsgBurst":                         4,
+	"queueDirect2MaxDepth":                            20000,
+	"queueDirect2MinMsgBurst":                         4,
+	"queueDirect3MaxDepth":                            20000,
+	"queueDirect3MinMsgBurst":                         4,
+	"queueGuaranteed1MaxDepth":                        20000,
+	"queueGuaranteed1MinMsgBurst":                     255,
+	"rejectMsgToSenderOnNoSubscriptionMatchEnabled":   false,
+	"replicationAllowClientConnectWhenStandbyEnabled": false,
+	"serviceMinKeepaliveTimeout":                      30,
+	"serviceSmfMaxConnectionCountPerClientUsername":   100,
+	"serviceSmfMinKeepaliveEnabled":                   false,
+	"serviceWebInactiveTimeout":                       30,
+	"serviceWebMaxConnectionCountPerClientUsername":   100,
+	"serviceWebMaxPayload":                            1000000,
+	"tcpCongestionWindowSize":                         2,
+	"tcpKeepaliveCount":                               5,
+	"tcpKeepaliveIdleTime":                            3,
+	"tcpKeepaliveInterval":                            1,
+	"tcpMaxSegmentSize":                               1460,
+	"tcpMaxWindowSize":                                256,
+	"tlsAllowDowngradeToPlainTextEnabled":             true,
+}
+
+// newClientProfile returns a client profile with the server defaults applied to the request
+func newClientProfile(req map[string]interface{}) map[string]interface{} {
+	profile := maps.Clone(clientProfileDefaults)
+	profile["eventClientProvisionedEndpointSpoolUsageThreshold"] = maps.Clone(clientProfileDefaults["eventClientProvisionedEndpointSpoolUsageThreshold"].(map[string]interface{}))
+	mergeClientProfile(profile, req)
+	profile["id"] = uuid.New().String()
+	profile["type"] = "clientProfile"
+	return profile
+}
+
+// mergeClientProfile applies the (partial) request to the profile
+func mergeClientProfile(profile map[string]interface{}, req map[string]interface{}) {
+	for k, v := range req {
+		if nested, ok := v.(map[string]interface{}); ok {
+			current, _ := profile[k].(map[string]interface{})
+			if current == nil {
+				current = map[string]interface{}{}
+			}
+			maps.Copy(current, nested)
+			profile[k] = current
+			continue
+		}
+		profile[k] = v
+	}
+}
+
+// clientProfileIndex returns the index of the client profile with the given name, or -1
+func clientProfileIndex(sInfo *ServiceInfo, name string) int {
+	for i, p := range sInfo.ClientProfiles {
+		if p["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleClientProfiles handles .../eventBrokerServices/{sid}/clientProfiles[/{name}]
+func (svr *Fakeserver) handleClientProfiles(w http.ResponseWriter, r *http.Request, sInfo *ServiceInfo, parts []string, body []byte) {
+	var jObj map[string]interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &jObj); err != nil {
+			log.Printf("fakeserver: Unmarshal of request failed: %s\n", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	if len(parts) == 7 {
+		switch r.Method {
+		case "GET":
+			svr.handleListClientProfiles(w, r, sInfo)
+		case "POST":
+			name := orDefault(jObj["name"], "")
+			if clientProfileIndex(sInfo, name) >= 0 {
+				http.Error(w, fmt.Sprintf("{\"message\":\"Client profile %s already exists\",\"errorId\":\"42\"}", name), http.StatusConflict)
+				return
+			}
+			sInfo.ClientProfiles = append(sInfo.ClientProfiles, newClientProfile(jObj))
+			svr.objects[sInfo.ID] = *sInfo
+			svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, name, "createClientProfile"))
+		default:
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		return
+	}
+	if len(parts) != 8 {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	name := parts[7]
+	idx := clientProfileIndex(sInfo, name)
+	if idx < 0 {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find client profile %s\",\"errorId\":\"42\"}", name), http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		svr.writeData(w, 200, sInfo.ClientProfiles[idx])
+	case "PATCH":
+		mergeClientProfile(sInfo.ClientProfiles[idx], jObj)
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, name, "updateClientProfile"))
+	case "PUT":
+		profile := newClientProfile(jObj)
+		profile["id"] = sInfo.ClientProfiles[idx]["id"]
+		profile["name"] = name
+		sInfo.ClientProfiles[idx] = profile
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, name, "updateClientProfile"))
+	case "DELETE":
+		sInfo.ClientProfiles = append(sInfo.ClientProfiles[:idx:idx], sInfo.ClientProfiles[idx+1:]...)
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, name, "deleteClientProfile"))
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+// handleListClientProfiles returns a page of client profile summaries, honoring pageNumber and pageSize
+func (svr *Fakeserver) handleListClientProfiles(w http.ResponseWriter, r *http.Request, sInfo *ServiceInfo) {
+	pageNumber, err := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+	if err != nil || pageNumber < 1 {
+		pageNumber = 1
+	}
+	pageSize, err := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 100
+	}
+	total := len(sInfo.ClientProfiles)
+	start := min((pageNumber-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	summaries := []interface{}{}
+	for _, p := range sInfo.ClientProfiles[start:end] {
+		summaries = append(summaries, map[string]interface{}{
+			"id":                                   p["id"],
+			"name":                                 p["name"],
+			"type":                                 p["type"],
+			"allowBridgeConnectionsEnabled":        p["allowBridgeConnectionsEnabled"],
+			"allowGuaranteedEndpointCreateEnabled": p["allowGuaranteedEndpointCreateEnabled"],
+			"allowGuaranteedMsgReceiveEnabled":     p["allowGuaranteedMsgReceiveEnabled"],
+			"allowGuaranteedMsgSendEnabled":        p["allowGuaranteedMsgSendEnabled"],
+			"allowTransactedSessionsEnabled":       p["allowTransactedSessionsEnabled"],
+		})
+	}
+	pagination := map[string]interface{}{
+		"pageNumber": pageNumber,
+		"pageSize":   pageSize,
+		"count":      total,
+		"totalPages": (total + pageSize - 1) / pageSize,
+	}
+	if end < total {
+		pagination["nextPage"] = pageNumber + 1
+	}
+	result := map[string]interface{}{
+		"data": summaries,
+		"meta": map[string]interface{}{
+			"pagination": pagination,
+		},
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("fakeserver: failed to marshal result: %s\n", err)
+		return
+	}
+	w.Header().Add("Content-Type", "json")
+	w.WriteHeader(200)
+	if _, err := w.Write(b); err != nil {
+		log.Printf("fakeserver: failed to write result: %s\n", err)
+	}
+}
@@ -0,0 +1,248 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// handleConnectionEndpoints handles .../eventBrokerServices/{sid}/connectionEndpoints[/{eid}]
+func (svr *Fakeserver) handleConnectionEndpoints(w http.ResponseWriter, method string, sInfo *ServiceInfo, parts []string, body []byte) {
+	if len(parts) == 7 && method == "POST" {
+		svr.handleCreateEndpoint(w, sInfo, body)
+		return
+	}
+	if len(parts) < 8 {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	idx := endpointIndex(sInfo, parts[7])
+	if idx < 0 {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find connection endpoint with id %s\",\"errorId\":\"42\"}", parts[7]), http.StatusNotFound)
+		return
+	}
+	if len(parts) >= 9 && parts[8] == "dnsNames" {
+		svr.handleDnsNames(w, method, sInfo, idx, parts, body)
+		return
+	}
+	if len(parts) != 8 {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	switch method {
+	case "GET":
+		svr.writeData(w, 200, endpointJSON(sInfo.Endpoints[idx]))
+	case "PATCH":
+		svr.handlePatchEndpoint(w, sInfo, idx, body)
+	case "DELETE":
+		// the endpoint is removed immediately, the operation completes later
+		endpointId := sInfo.Endpoints[idx].ID
+		sInfo.Endpoints = append(sInfo.Endpoints[:idx:idx], sInfo.Endpoints[idx+1:]...)
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, endpointId, "deleteConnectionEndpoint"))
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+func (svr *Fakeserver) handleCreateEndpoint(w http.ResponseWriter, sInfo *ServiceInfo, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		log.Printf("fakeserver: Unmarshal of request failed: %s\n", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	name := orDefault(jObj["name"], "")
+	for _, e := range sInfo.Endpoints {
+		if e.Name == name {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Connection endpoint %s already exists\",\"errorId\":\"42\"}", name), http.StatusConflict)
+			return
+		}
+	}
+	endpoint := EndpointInfo{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: orDefault(jObj["description"], ""),
+		AccessType:  orDefault(jObj["accessType"], "PUBLIC"),
+		HostNames:   []string{name + ".test-host1"},
+		Ports:       parsePorts(jObj["ports"]),
+		Created:     time.Now(),
+	}
+	sInfo.Endpoints = append(sInfo.Endpoints, endpoint)
+	svr.objects[sInfo.ID] = *sInfo
+	svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, endpoint.ID, "createConnectionEndpoint"))
+}
+
+func (svr *Fakeserver) handlePatchEndpoint(w http.ResponseWriter, sInfo *ServiceInfo, idx int, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		log.Printf("fakeserver: Unmarshal of request failed: %s\n", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	endpoint := sInfo.Endpoints[idx]
+	if jObj["name"] != nil {
+		endpoint.Name = jObj["name"].(string)
+	}
+	if jObj["description"] != nil {
+		endpoint.Description = jObj["description"].(string)
+	}
+	if jObj["accessType"] != nil {
+		endpoint.AccessType = jObj["accessType"].(string)
+	}
+	if jObj["ports"] != nil {
+		endpoint.Ports = parsePorts(jObj["ports"])
+	}
+	sInfo.Endpoints[idx] = endpoint
+	svr.objects[sInfo.ID] = *sInfo
+	svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, endpoint.ID, "updateConnectionEndpoint"))
+}
+
+// handleDnsNames handles .../connectionEndpoints/{eid}/dnsNames[/{dnsName}[/move]]
+func (svr *Fakeserver) handleDnsNames(w http.ResponseWriter, method string, sInfo *ServiceInfo, idx int, parts []string, body []byte) {
+	endpoint := &sInfo.Endpoints[idx]
+	switch {
+	case len(parts) == 9 && method == "GET":
+		dnsNames := []interface{}{}
+		for _, dnsName := range endpoint.DnsNames {
+			dnsNames = append(dnsNames, dnsNameJSON(dnsName))
+		}
+		svr.writeData(w, 200, dnsNames)
+	case len(parts) == 9 && method == "POST":
+		var jObj map[string]interface{}
+		if err := json.Unmarshal(body, &jObj); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		dnsName := orDefault(jObj["dnsName"], "")
+		if slices.Contains(endpoint.DnsNames, dnsName) {
+			http.Error(w, fmt.Sprintf("{\"message\":\"DNS name %s already exists\",\"errorId\":\"42\"}", dnsName), http.StatusConflict)
+			return
+		}
+		endpoint.DnsNames = append(endpoint.DnsNames, dnsName)
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, dnsName, "createDnsName"))
+	case len(parts) == 10 && method == "DELETE":
+		if !slices.Contains(endpoint.DnsNames, parts[9]) {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find DNS name %s\",\"errorId\":\"42\"}", parts[9]), http.StatusNotFound)
+			return
+		}
+		endpoint.DnsNames = slices.DeleteFunc(endpoint.DnsNames, func(n string) bool { return n == parts[9] })
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, parts[9], "deleteDnsName"))
+	case len(parts) == 11 && parts[10] == "move" && method == "POST":
+		svr.handleMoveDnsName(w, sInfo, idx, parts[9], body)
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+func (svr *Fakeserver) handleMoveDnsName(w http.ResponseWriter, sInfo *ServiceInfo, idx int, dnsName string, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !slices.Contains(sInfo.Endpoints[idx].DnsNames, dnsName) {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find DNS name %s\",\"errorId\":\"42\"}", dnsName), http.StatusNotFound)
+		return
+	}
+	targetSid := orDefault(jObj["targetServiceId"], sInfo.ID)
+	targetInfo, ok := svr.objects[targetSid]
+	if !ok {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", targetSid), http.StatusNotFound)
+		return
+	}
+	targetEid := orDefault(jObj["targetConnectionEndpointId"], "")
+	if targetSid == sInfo.ID {
+		targetInfo = *sInfo
+	}
+	targetIdx := endpointIndex(&targetInfo, targetEid)
+	if targetIdx < 0 {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find connection endpoint with id %s\",\"errorId\":\"42\"}", targetEid), http.StatusNotFound)
+		return
+	}
+	// the name is moved atomically, so it never becomes unresolvable
+	sInfo.Endpoints[idx].DnsNames = slices.DeleteFunc(sInfo.Endpoints[idx].DnsNames, func(n string) bool { return n == dnsName })
+	svr.objects[sInfo.ID] = *sInfo
+	if targetSid == sInfo.ID {
+		targetInfo = *sInfo
+	}
+	targetInfo.Endpoints[targetIdx].DnsNames = append(targetInfo.Endpoints[targetIdx].DnsNames, dnsName)
+	svr.objects[targetSid] = targetInfo
+	svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, dnsName, "moveDnsName"))
+}
+
+// dnsNameJSON returns a DNS name in api format
+func dnsNameJSON(dnsName string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            "dns-" + dnsName,
+		"dnsName":       dnsName,
+		"dnsRecordType": "CNAME",
+		"domainType":    "CustomerManaged",
+	}
+}
+
+// endpointIndex returns the index of the endpoint with the given id, or -1
+func endpointIndex(sInfo *ServiceInfo, endpointId string) int {
+	for i, e := range sInfo.Endpoints {
+		if e.ID == endpointId {
+			return i
+		}
+	}
+	return -1
+}
+
+// parsePorts converts the api port list to a map protocol -> port
+func parsePorts(reqPorts interface{}) map[string]int32 {
+	ports := map[string]int32{}
+	if reqPorts != nil {
+		for _, p := range reqPorts.([]interface{}) {
+			pObj := p.(map[string]interface{})
+			ports[pObj["protocol"].(string)] = orDefaultInt32(pObj["port"], 0)
+		}
+	}
+	return ports
+}
+
+// newOperation registers a PENDING operation, which succeeds after a certain delay (see handleGetOperation)
+func (svr *Fakeserver) newOperation(sid string, resourceId string, operationType string) OperationInfo {
+	opInfo := OperationInfo{
+		ID:            uuid.New().String(),
+		ServiceId:     sid,
+		ResourceId:    resourceId,
+		OperationType: operationType,
+		Status:        "PENDING",
+		Created:       time.Now(),
+	}
+	svr.operations[opInfo.ID] = opInfo
+	return opInfo
+}
+
+// writeData writes the given object as {"data": obj}
+func (svr *Fakeserver) writeData(w http.ResponseWriter, statusCode int, data interface{}) {
+	result := map[string]interface{}{
+		"data": data,
+		"meta": map[string]interface{}{
+			"additionalProp": map[string]interface{}{},
+		},
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("fakeserver: failed to marshal result: %s\n", err)
+		return
+	}
+	if svr.debug {
+		log.Printf("fakeserver: BODY %s", string(b))
+	}
+	w.Header().Add("Content-Type", "json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(b); err != nil {
+		log.Printf("fakeserver: failed to write result: %s\n", err)
+	}
+}
@@ -21,33 +21,66 @@ import (
 
 /* Fakeserver represents a HTTP server with objects to hold and return*/
 type Fakeserver struct {
-	server  *http.Server
-	objects map[string]ServiceInfo
-	debug   bool
-	running bool
-	baseSid int
+	server     *http.Server
+	objects    map[string]ServiceInfo
+	operations map[string]OperationInfo
+	activities map[string]MaintenanceActivityInfo
+	windows    map[string]MaintenanceWindowInfo
+	debug      bool
+	running    bool
+	baseSid    int
+}
+
+type OperationInfo struct {
+	ID            string
+	ServiceId     string
+	ResourceId    string // defaults to ServiceId
+	OperationType string
+	Status        string
+	ErrorMessage  string
+	Created       time.Time
+}
+
+type EndpointInfo struct {
+	ID          string
+	Name        string
+	Description string
+	AccessType  string
+	HostNames   []string
+	Ports       map[string]int32
+	DnsNames    []string
+	Created     time.Time
 }
 
 type ServiceInfo struct {
-	ID                          string
-	ServiceClassId              string
-	DatacenterId                string
-	Name                        string
-	State                       string
-	MsgVpnName                  string
-	EventBrokerVersion          string
-	CustomRouterName            string
-	ClusterName                 string
-	MaxSpoolUsage               int32
-	Created                     time.Time
-	Updated                     time.Time
-	MissionControlUserName      string
-	MissionControlPassword      string
-	MissionControlToken         string
-	MgmtAdminUserName           string
-	MgmtAdminPassword           string
-	ServiceConnectionEndpointId string
-	hostnames                   []string
+	ID                        string
+	ServiceClassId            string
+	DatacenterId              string
+	Name                      string
+	State                     string
+	MsgVpnName                string
+	EventBrokerVersion        string
+	CustomRouterName          string
+	ClusterName               string
+	MaxSpoolUsage             int32
+	Created                   time.Time
+	Updated                   time.Time
+	MissionControlUserName    string
+	MissionControlPassword    string
+	MissionControlToken       string
+	MgmtAdminUserName         string
+	MgmtAdminPassword         string
+	Endpoints                 []EndpointInfo
+	Locked                    bool
+	EnvironmentId             string
+	OwnedBy                   string
+	RedundancyGroupSslEnabled bool
+	ClientProfiles            []map[string]interface{}
+	SempObjects               map[string]map[string]interface{}
+	SempBasicAuthEnabled      bool
+	ServerCertificates        []ServerCertificateInfo
+	ActiveNode                string
+	SwitchoverStarted         time.Time
 }
 
 /* NewFakeServer creates a HTTP server used for tests and debugging*/
@@ -55,10 +88,13 @@ func NewFakeServer(iPort int, iObjects map[string]ServiceInfo, iStart bool, iDeb
 	serverMux := http.NewServeMux()
 
 	svr := &Fakeserver{
-		debug:   iDebug,
-		objects: iObjects,
-		running: false,
-		baseSid: iBaseSid, // 0 means generate uuids
+		debug:      iDebug,
+		objects:    iObjects,
+		operations: make(map[string]OperationInfo),
+		activities: make(map[string]MaintenanceActivityInfo),
+		windows:    make(map[string]MaintenanceWindowInfo),
+		running:    false,
+		baseSid:    iBaseSid, // 0 means generate uuids
 	}
 
 	serverMux.HandleFunc("/api/v2/missionControl/", svr.handleBrokerServices)
@@ -86,6 +122,12 @@ func (svr *Fakeserver) SetBaseSid(sid int) {
 	log.Printf("fakeserver: setting baseSid to %d\n", svr.baseSid)
 }
 
+/*HasService returns whether a service with the given id (still) exists*/
+func (svr *Fakeserver) HasService(sid string) bool {
+	_, ok := svr.objects[sid]
+	return ok
+}
+
 func (svr *Fakeserver) safeServe() {
 	err := svr.server.ListenAndServe()
 	if err != nil {
@@ -184,25 +226,38 @@ func (svr *Fakeserver) handleCreate(w http.ResponseWriter, body []byte) {
 	}
 	// parse and store obj
 	sInfo := ServiceInfo{
-		ID:                          sid,
-		Name:                        jObj["name"].(string),
-		State:                       "PENDING",
-		ServiceClassId:              jObj["serviceClassId"].(string),
-		DatacenterId:                jObj["datacenterId"].(string),
-		ClusterName:                 orDefault(jObj["clusterName"], "test-cluster1"),
-		MsgVpnName:                  orDefault(jObj["msgVpnName"], "test-vpn1"),
-		EventBrokerVersion:          orDefault(jObj["eventBrokerVersion"], "1.0.0"),
-		CustomRouterName:            customRouterName,
-		MaxSpoolUsage:               orDefaultInt32(jObj["maxSpoolUsage"], 20),
-		Created:                     time.Now(),
-		MissionControlUserName:      "mc-user",
-		MissionControlPassword:      "mc-passwd",
-		MgmtAdminUserName:           "ma-user",
-		MgmtAdminPassword:           "ma-passwd",
-		ServiceConnectionEndpointId: "test-endpoint",
-		hostnames:                   []string{"test-host1", "test-host2"},
-	}
+		ID:                        sid,
+		Name:                      jObj["name"].(string),
+		State:                     "PENDING",
+		ServiceClassId:            jObj["serviceClassId"].(string),
+		DatacenterId:              jObj["datacenterId"].(string),
+		ClusterName:               orDefault(jObj["clusterName"], "test-cluster1"),
+		MsgVpnName:                orDefault(jObj["msgVpnName"], "test-vpn1"),
+		EventBrokerVersion:        orDefault(jObj["eventBrokerVersion"], "1.0.0"),
+		CustomRouterName:          customRouterName,
+		MaxSpoolUsage:             orDefaultInt32(jObj["maxSpoolUsage"], 20),
+		Created:                   time.Now(),
+		MissionControlUserName:    "mc-user",
+		MissionControlPassword:    "mc-passwd",
+		MgmtAdminUserName:         "ma-user",
+		MgmtAdminPassword:         "ma-passwd",
+		Endpoints:                 parseEndpoints(jObj["serviceConnectionEndpoints"]),
+		Locked:                    jObj["locked"] != nil && jObj["locked"].(bool),
+		EnvironmentId:             orDefault(jObj["environmentId"], "test-env1"),
+		OwnedBy:                   "test-user1",
+		RedundancyGroupSslEnabled: jObj["redundancyGroupSslEnabled"] != nil && jObj["redundancyGroupSslEnabled"].(bool),
+		SempBasicAuthEnabled:      true,
+		ClientProfiles:            []map[string]interface{}{newClientProfile(map[string]interface{}{"name": "default"})},
+	}
+	sInfo.SempObjects = newSempObjects(sInfo.MsgVpnName)
 	svr.objects[sid] = sInfo
+	svr.operations["O"+sid] = OperationInfo{
+		ID:            "O" + sid,
+		ServiceId:     sid,
+		OperationType: "createService",
+		Status:        "PENDING",
+		Created:       sInfo.Created,
+	}
 	if svr.debug {
 		log.Printf("fakeserver: Created Info: %v", sInfo)
 	}
@@ -237,15 +292,24 @@ func (svr *Fakeserver) handleCreate(w http.ResponseWriter, body []byte) {
 	}
 }
 
-func (svr *Fakeserver) handleGet(w http.ResponseWriter, sInfo *ServiceInfo, id string) {
-	// complete creation after a certain delay, so we can test PENDING answers
+// complete creation after a certain delay, so we can test PENDING answers.
+// Services named "fail..." end up in state FAILED
+func (svr *Fakeserver) progressCreation(sInfo *ServiceInfo, id string) {
 	if sInfo.State == "PENDING" {
 		if time.Since(sInfo.Created).Seconds() > 5.0 {
-			sInfo.State = "COMPLETED"
+			if strings.HasPrefix(sInfo.Name, "fail") {
+				sInfo.State = "FAILED"
+			} else {
+				sInfo.State = "COMPLETED"
+			}
 		}
 		// writeback change
 		svr.objects[id] = *sInfo
 	}
+}
+
+func (svr *Fakeserver) handleGet(w http.ResponseWriter, sInfo *ServiceInfo, id string) {
+	svr.progressCreation(sInfo, id)
 	if svr.debug {
 		log.Printf("fakeserver: GET service %v", sInfo)
 	}
@@ -260,6 +324,9 @@ func (svr *Fakeserver) handleGet(w http.ResponseWriter, sInfo *ServiceInfo, id s
 			"createdTime":               sInfo.Created.Format(time.RFC3339),
 			"creationState":             sInfo.State,
 			"eventBrokerServiceVersion": sInfo.EventBrokerVersion,
+			"locked":                    sInfo.Locked,
+			"environmentId":             sInfo.EnvironmentId,
+			"ownedBy":                   sInfo.OwnedBy,
 			"broker": map[string]interface{}{
 				"cluster": map[string]interface{}{
 					"name":              sInfo.ClusterName,
@@ -279,14 +346,10 @@ func (svr *Fakeserver) handleGet(w http.ResponseWriter, sInfo *ServiceInfo, id s
 						},
 					},
 				},
-				"maxSpoolUsage": sInfo.MaxSpoolUsage,
-			},
-			"serviceConnectionEndpoints": []interface{}{
-				map[string]interface{}{
-					"id":        sInfo.ServiceConnectionEndpointId,
-					"hostnames": sInfo.hostnames,
-				},
+				"maxSpoolUsage":             sInfo.MaxSpoolUsage,
+				"redundancyGroupSslEnabled": sInfo.RedundancyGroupSslEnabled,
 			},
+			"serviceConnectionEndpoints": endpointsJSON(sInfo.Endpoints),
 		},
 		"meta": map[string]interface{}{
 			"additionalProp": map[string]interface{}{},
@@ -332,7 +395,15 @@ func (svr *Fakeserver) handlePatch(w http.ResponseWriter, sInfo *ServiceInfo, id
 	}
 
 	// handle update - only supported when get returns actual completed service
-	sInfo.Name = jObj["name"].(string)
+	if jObj["name"] != nil {
+		sInfo.Name = jObj["name"].(string)
+	}
+	if jObj["locked"] != nil {
+		sInfo.Locked = jObj["locked"].(bool)
+	}
+	if jObj["ownedBy"] != nil {
+		sInfo.OwnedBy = jObj["ownedBy"].(string)
+	}
 	sInfo.State = "PENDING"
 	sInfo.Updated = time.Now()
 
@@ -349,6 +420,9 @@ func (svr *Fakeserver) handlePatch(w http.ResponseWriter, sInfo *ServiceInfo, id
 			"updatedTime":               sInfo.Updated.Format(time.RFC3339),
 			"creationState":             sInfo.State,
 			"eventBrokerServiceVersion": sInfo.EventBrokerVersion,
+			"locked":                    sInfo.Locked,
+			"environmentId":             sInfo.EnvironmentId,
+			"ownedBy":                   sInfo.OwnedBy,
 			"broker": map[string]interface{}{
 				"cluster": map[string]interface{}{
 					"name":              sInfo.ClusterName,
@@ -368,14 +442,10 @@ func (svr *Fakeserver) handlePatch(w http.ResponseWriter, sInfo *ServiceInfo, id
 						},
 					},
 				},
-				"maxSpoolUsage": sInfo.MaxSpoolUsage,
-			},
-			"serviceConnectionEndpoints": []interface{}{
-				map[string]interface{}{
-					"id":        sInfo.ServiceConnectionEndpointId,
-					"hostnames": sInfo.hostnames,
-				},
+				"maxSpoolUsage":             sInfo.MaxSpoolUsage,
+				"redundancyGroupSslEnabled": sInfo.RedundancyGroupSslEnabled,
 			},
+			"serviceConnectionEndpoints": endpointsJSON(sInfo.Endpoints),
 		},
 		"meta": map[string]interface{}{
 			"additionalProp": map[string]interface{}{},
@@ -404,21 +474,110 @@ func (svr *Fakeserver) handleDelete(w http.ResponseWriter, sInfo *ServiceInfo, i
 	if svr.debug {
 		log.Printf("fakeserver: DELETE service %v", sInfo)
 	}
-	// handle delete
-	delete(svr.objects, id)
-	// return status DELETING
+	if sInfo.Locked {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Event broker service %s is locked and cannot be deleted\",\"errorId\":\"42\"}", id), http.StatusBadRequest)
+		return
+	}
+	// handle delete - the service is removed when the operation has finished (see handleGetOperation)
+	opInfo := OperationInfo{
+		ID:            "D" + sInfo.ID,
+		ServiceId:     sInfo.ID,
+		OperationType: "deleteService",
+		Status:        "PENDING",
+		Created:       time.Now(),
+	}
+	svr.operations[opInfo.ID] = opInfo
+	// return status PENDING
+	svr.writeOperation(w, 202, opInfo)
+}
+
+func (svr *Fakeserver) handlePatchMessageSpool(w http.ResponseWriter, sInfo *ServiceInfo, id string, body []byte) {
+	var jObj map[string]interface{}
+
+	err := json.Unmarshal(body, &jObj)
+	if err != nil {
+		log.Printf("fakeserver: Unmarshal of request failed: %s\n", err)
+		log.Printf("\nBEGIN passed data:\n%s\nEND passed data.", string(body))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	spoolSize := orDefaultInt32(jObj["messageSpoolSizeInGB"], sInfo.MaxSpoolUsage)
+	if spoolSize < sInfo.MaxSpoolUsage {
+		http.Error(w, "{\"message\":\"The message spool size cannot be decreased\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	if svr.debug {
+		log.Printf("fakeserver: PATCH message spool of service %s to %d", id, spoolSize)
+	}
+
+	// for simplicity the spool size is changed right away
+	sInfo.MaxSpoolUsage = spoolSize
+	sInfo.Updated = time.Now()
+	svr.objects[id] = *sInfo
+
+	opInfo := OperationInfo{
+		ID:            "S" + uuid.New().String(),
+		ServiceId:     id,
+		OperationType: "serviceScaleUp",
+		Status:        "PENDING",
+		Created:       time.Now(),
+	}
+	svr.operations[opInfo.ID] = opInfo
+	svr.writeOperation(w, 202, opInfo)
+}
+
+// handlePutSempBasicAuth enables or disables basic authentication for management access, which can't be read back
+func (svr *Fakeserver) handlePutSempBasicAuth(w http.ResponseWriter, sInfo *ServiceInfo, id string, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		http.Error(w, "{\"message\":\"Invalid request body\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	enabled, ok := jObj["enabled"].(bool)
+	if !ok {
+		http.Error(w, "{\"message\":\"enabled must be a boolean\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	if svr.debug {
+		log.Printf("fakeserver: PUT semp basic auth of service %s to %t", id, enabled)
+	}
+	// Services named "authfail..." can't change the setting
+	if strings.HasPrefix(sInfo.Name, "authfail") {
+		http.Error(w, "{\"message\":\"Could not change basic authentication\",\"errorId\":\"42\"}", http.StatusInternalServerError)
+		return
+	}
+	sInfo.SempBasicAuthEnabled = enabled
+	svr.objects[id] = *sInfo
+	svr.writeJSON(w, 200, map[string]interface{}{
+		"data": map[string]interface{}{"enabled": enabled},
+		"meta": map[string]interface{}{},
+	})
+}
+
+// write an operation response with the given status code
+func (svr *Fakeserver) writeOperation(w http.ResponseWriter, statusCode int, opInfo OperationInfo) {
+	resourceId := opInfo.ResourceId
+	if resourceId == "" {
+		resourceId = opInfo.ServiceId
+	}
 	result := map[string]interface{}{
 		"data": map[string]interface{}{
-			"id":          "O" + sInfo.ID,
-			"resourceId":  sInfo.ID,
-			"name":        sInfo.Name,
-			"createdTime": sInfo.Created.Format(time.RFC3339),
-			"status":      "PENDING",
+			"id":            opInfo.ID,
+			"resourceId":    resourceId,
+			"operationType": opInfo.OperationType,
+			"createdTime":   opInfo.Created.Format(time.RFC3339),
+			"status":        opInfo.Status,
 		},
 		"meta": map[string]interface{}{
 			"additionalProp": map[string]interface{}{},
 		},
 	}
+	if opInfo.ErrorMessage != "" {
+		result["data"].(map[string]interface{})["error"] = map[string]interface{}{
+			"errorId": "42",
+			"message": opInfo.ErrorMessage,
+		}
+	}
 	b, err := json.Marshal(result)
 	if err != nil {
 		log.Printf("fakeserver: failed to marshal result: %s\n", err)
@@ -428,13 +587,52 @@ func (svr *Fakeserver) handleDelete(w http.ResponseWriter, sInfo *ServiceInfo, i
 		log.Printf("fakeserver: BODY %s", string(b))
 	}
 	w.Header().Add("Content-Type", "json")
-	w.WriteHeader(202)
+	w.WriteHeader(statusCode)
 	_, err2 := w.Write(b)
 	if err2 != nil {
 		log.Printf("fakeserver: failed to write result: %s\n", err)
 	}
 }
 
+func (svr *Fakeserver) handleGetOperation(w http.ResponseWriter, sid string, opId string) {
+	opInfo, ok := svr.operations[opId]
+	if !ok || opInfo.ServiceId != sid {
+		log.Printf("fakeserver: Operation with ID %s not found", opId)
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find operation with id %s\",\"errorId\":\"42\"}", opId), http.StatusNotFound)
+		return
+	}
+	// complete the operation after a certain delay, so we can test PENDING answers
+	if opInfo.Status == "PENDING" {
+		switch opInfo.OperationType {
+		case "createService":
+			// the create operation follows the creation state of its service
+			if sInfo, ok := svr.objects[sid]; ok {
+				svr.progressCreation(&sInfo, sid)
+				switch sInfo.State {
+				case "COMPLETED":
+					opInfo.Status = "SUCCEEDED"
+				case "FAILED":
+					opInfo.Status = "FAILED"
+					opInfo.ErrorMessage = "Creation of event broker service failed"
+				}
+			}
+		default:
+			if time.Since(opInfo.Created).Seconds() > 3.0 {
+				opInfo.Status = "SUCCEEDED"
+				if opInfo.OperationType == "deleteService" {
+					delete(svr.objects, sid)
+				}
+			}
+		}
+		// writeback change
+		svr.operations[opId] = opInfo
+	}
+	if svr.debug {
+		log.Printf("fakeserver: GET operation %v", opInfo)
+	}
+	svr.writeOperation(w, 200, opInfo)
+}
+
 func (svr *Fakeserver) handleBrokerServices(w http.ResponseWriter, r *http.Request) {
 
 	var sInfo ServiceInfo
@@ -448,9 +646,91 @@ func (svr *Fakeserver) handleBrokerServices(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if (len(parts) == 5 || (len(parts) == 6 && parts[5] == "")) && r.Method == "POST" {
+	if len(parts) >= 6 && parts[4] == "maintenanceActivities" {
+		svr.handleMaintenanceActivities(w, r.Method, parts)
+		return
+	} else if len(parts) >= 5 && parts[4] == "maintenanceWindows" {
+		svr.handleMaintenanceWindows(w, r.Method, parts, body)
+		return
+	} else if (len(parts) == 5 || (len(parts) == 6 && parts[5] == "")) && r.Method == "POST" {
 		svr.handleCreate(w, body)
 		return
+	} else if len(parts) == 8 && parts[6] == "operations" && r.Method == "GET" {
+		// operations outlive their (deleted) service
+		svr.handleGetOperation(w, parts[5], parts[7])
+		return
+	} else if len(parts) == 7 && parts[6] == "messageSpool" && r.Method == "PATCH" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handlePatchMessageSpool(w, &sInfo, parts[5], body)
+		return
+	} else if len(parts) == 7 && parts[6] == "sempBasicAuth" && r.Method == "PUT" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handlePutSempBasicAuth(w, &sInfo, parts[5], body)
+		return
+	} else if len(parts) == 7 && (parts[6] == "brokerState" && r.Method == "GET" || parts[6] == "switchover" && r.Method == "POST") {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		if parts[6] == "brokerState" {
+			svr.handleBrokerState(w, &sInfo)
+		} else {
+			svr.handleSwitchover(w, &sInfo)
+		}
+		return
+	} else if len(parts) >= 7 && (parts[6] == "upgradeReadiness" && r.Method == "GET" || parts[6] == "upgrades") {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		if parts[6] == "upgradeReadiness" {
+			svr.handleUpgradeReadiness(w, &sInfo)
+		} else {
+			svr.handleUpgrades(w, r.Method, &sInfo, parts, body)
+		}
+		return
+	} else if len(parts) >= 7 && parts[6] == "serverCertificates" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handleServerCertificates(w, r.Method, &sInfo, parts, body)
+		return
+	} else if len(parts) >= 7 && parts[6] == "connectionEndpoints" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handleConnectionEndpoints(w, r.Method, &sInfo, parts, body)
+		return
+	} else if len(parts) >= 10 && parts[6] == "broker" && parts[7] == "SEMP" && parts[8] == "v2" && parts[9] == "config" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handleSemp(w, r, &sInfo, parts, body)
+		return
+	} else if len(parts) >= 7 && parts[6] == "clientProfiles" {
+		sInfo, ok = svr.objects[parts[5]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Could not find event broker service with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+			return
+		}
+		svr.handleClientProfiles(w, r, &sInfo, parts, body)
+		return
 	} else if len(parts) == 6 {
 		// an obj was specified.
 		id = parts[5]
@@ -465,6 +745,7 @@ func (svr *Fakeserver) handleBrokerServices(w http.ResponseWriter, r *http.Reque
 		}
 		switch r.Method {
 		case "GET":
+			svr.completeUpgrades(&sInfo)
 			svr.handleGet(w, &sInfo, id)
 			return
 		case "PATCH":
@@ -486,6 +767,71 @@ func (svr *Fakeserver) handleBrokerServices(w http.ResponseWriter, r *http.Reque
 
 }
 
+// parseEndpoints returns the requested service connection endpoints or the default public endpoint
+func parseEndpoints(reqEndpoints interface{}) []EndpointInfo {
+	if reqEndpoints == nil || len(reqEndpoints.([]interface{})) == 0 {
+		return []EndpointInfo{{
+			ID:         "test-endpoint",
+			Name:       "test-endpoint",
+			AccessType: "PUBLIC",
+			HostNames:  []string{"test-host1", "test-host2"},
+			Ports: map[string]int32{
+				"serviceSmfTlsListenPort":        55443,
+				"serviceManagementTlsListenPort": 943,
+				"serviceWebTlsListenPort":        443,
+			},
+		}}
+	}
+	endpoints := []EndpointInfo{}
+	for i, e := range reqEndpoints.([]interface{}) {
+		eObj := e.(map[string]interface{})
+		name := orDefault(eObj["name"], fmt.Sprintf("endpoint%d", i))
+		endpoints = append(endpoints, EndpointInfo{
+			ID:          fmt.Sprintf("test-endpoint%d", i),
+			Name:        name,
+			Description: orDefault(eObj["description"], ""),
+			AccessType:  orDefault(eObj["accessType"], "PUBLIC"),
+			HostNames:   []string{name + ".test-host1"},
+			Ports:       parsePorts(eObj["ports"]),
+		})
+	}
+	return endpoints
+}
+
+// endpointsJSON returns the service connection endpoints in api format
+func endpointsJSON(endpoints []EndpointInfo) []interface{} {
+	result := []interface{}{}
+	for _, e := range endpoints {
+		result = append(result, endpointJSON(e))
+	}
+	return result
+}
+
+// endpointJSON returns a single service connection endpoint in api format
+func endpointJSON(e EndpointInfo) map[string]interface{} {
+	ports := []interface{}{}
+	for protocol, port := range e.Ports {
+		ports = append(ports, map[string]interface{}{
+			"protocol": protocol,
+			"port":     port,
+		})
+	}
+	// endpoints become available after a certain delay, so we can test pending states
+	creationState := "completed"
+	if time.Since(e.Created).Seconds() < 3.0 {
+		creationState = "pending"
+	}
+	return map[string]interface{}{
+		"id":            e.ID,
+		"name":          e.Name,
+		"description":   e.Description,
+		"accessType":    e.AccessType,
+		"hostNames":     e.HostNames,
+		"ports":         ports,
+		"creationState": creationState,
+	}
+}
+
 func orDefault(s interface{}, ds string) string {
 	if s != nil && s.(string) != "" {
 		return s.(string)
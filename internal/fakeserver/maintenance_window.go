@@ -0,0 +1,134 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceWindowInfo is a recurring maintenance window of a maintenance schedule
+type MaintenanceWindowInfo struct {
+	ID                         string
+	Name                       string
+	MaintenanceScheduleId      string
+	DurationInHours            int32
+	RecurrenceFrequency        string
+	RecurrenceDayOfWeek        string
+	RecurrenceDayWeekly        string
+	RecurrenceDayOfWeekMonthly string
+	StartTime                  time.Time
+	Created                    time.Time
+	Updated                    time.Time
+}
+
+/*HasMaintenanceWindow returns whether a maintenance window with the given id (still) exists*/
+func (svr *Fakeserver) HasMaintenanceWindow(id string) bool {
+	_, ok := svr.windows[id]
+	return ok
+}
+
+// handleMaintenanceWindows handles .../maintenanceWindows[/{id}]
+func (svr *Fakeserver) handleMaintenanceWindows(w http.ResponseWriter, method string, parts []string, body []byte) {
+	if len(parts) == 5 || (len(parts) == 6 && parts[5] == "") {
+		switch method {
+		case "GET":
+			windows := []interface{}{}
+			for _, mw := range svr.windows {
+				windows = append(windows, maintenanceWindowJSON(mw))
+			}
+			svr.writeData(w, 200, windows)
+		case "POST":
+			mw := MaintenanceWindowInfo{ID: uuid.New().String(), Created: time.Now()}
+			if !parseMaintenanceWindow(w, body, &mw) {
+				return
+			}
+			svr.windows[mw.ID] = mw
+			svr.writeJSON(w, 201, map[string]interface{}{"data": maintenanceWindowJSON(mw)})
+		default:
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		return
+	}
+	mw, ok := svr.windows[parts[5]]
+	if !ok {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find maintenance window with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 6 && method == "GET":
+		svr.writeJSON(w, 200, map[string]interface{}{"data": maintenanceWindowJSON(mw)})
+	case len(parts) == 6 && method == "PUT":
+		if !parseMaintenanceWindow(w, body, &mw) {
+			return
+		}
+		svr.windows[mw.ID] = mw
+		svr.writeJSON(w, 200, map[string]interface{}{"data": maintenanceWindowJSON(mw)})
+	case len(parts) == 6 && method == "DELETE":
+		delete(svr.windows, mw.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+// parseMaintenanceWindow applies a MaintenanceWindowRequest, writing a 400 for invalid recurrences
+func parseMaintenanceWindow(w http.ResponseWriter, body []byte, mw *MaintenanceWindowInfo) bool {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		http.Error(w, "{\"message\":\"Invalid request body\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return false
+	}
+	startTime, err := time.Parse(time.RFC3339, orDefault(jObj["startTime"], ""))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Invalid startTime: %s\",\"errorId\":\"42\"}", err), http.StatusBadRequest)
+		return false
+	}
+	frequency := orDefault(jObj["recurrenceFrequency"], "")
+	dayWeekly := orDefault(jObj["recurrenceDayWeekly"], "")
+	dayOfWeekMonthly := orDefault(jObj["recurrenceDayOfWeekMonthly"], "")
+	if frequency == "WEEKLY" && dayOfWeekMonthly != "" || frequency == "MONTHLY" && dayWeekly != "" ||
+		frequency != "WEEKLY" && frequency != "MONTHLY" {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Invalid recurrence for frequency %s\",\"errorId\":\"42\"}", frequency), http.StatusBadRequest)
+		return false
+	}
+	mw.Name = orDefault(jObj["name"], "")
+	mw.MaintenanceScheduleId = orDefault(jObj["maintenanceScheduleId"], "")
+	mw.DurationInHours = orDefaultInt32(jObj["durationInHours"], 0)
+	mw.RecurrenceFrequency = frequency
+	mw.RecurrenceDayOfWeek = orDefault(jObj["recurrenceDayOfWeek"], "")
+	mw.RecurrenceDayWeekly = dayWeekly
+	mw.RecurrenceDayOfWeekMonthly = dayOfWeekMonthly
+	// like the api the start time is returned in UTC
+	mw.StartTime = startTime.UTC()
+	mw.Updated = time.Now()
+	return true
+}
+
+func maintenanceWindowJSON(mw MaintenanceWindowInfo) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":                     mw.ID,
+		"maintenanceScheduleId":  mw.MaintenanceScheduleId,
+		"maintenanceType":        "DATA_PATH",
+		"maintenanceWindowScope": "ENVIRONMENT",
+		"durationInHours":        mw.DurationInHours,
+		"recurrenceFrequency":    mw.RecurrenceFrequency,
+		"recurrenceDayOfWeek":    mw.RecurrenceDayOfWeek,
+		"startTime":              mw.StartTime.Format(time.RFC3339),
+		"createdTime":            mw.Created.UTC().Format(time.RFC3339),
+		"updatedTime":            mw.Updated.UTC().Format(time.RFC3339),
+		"type":                   "maintenanceWindow",
+	}
+	if mw.Name != "" {
+		result["name"] = mw.Name
+	}
+	if mw.RecurrenceDayWeekly != "" {
+		result["recurrenceDayWeekly"] = mw.RecurrenceDayWeekly
+	}
+	if mw.RecurrenceDayOfWeekMonthly != "" {
+		result["recurrenceDayOfWeekMonthly"] = mw.RecurrenceDayOfWeekMonthly
+	}
+	return result
+}
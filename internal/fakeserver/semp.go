@@ -0,0 +1,273 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sempIdentifiers are the identifying attributes of the objects in a SEMP collection, composite keys are comma separated
+var sempIdentifiers = map[string]string{
+	"msgVpns":                  "msgVpnName",
+	"queues":                   "queueName",
+	"subscriptions":            "subscriptionTopic",
+	"aclProfiles":              "aclProfileName",
+	"publishTopicExceptions":   "publishTopicExceptionSyntax,publishTopicException",
+	"subscribeTopicExceptions": "subscribeTopicExceptionSyntax,subscribeTopicException",
+	"clientUsernames":          "clientUsername",
+	"clientProfiles":           "clientProfileName",
+	"bridges":                  "bridgeName",
+}
+
+// sempDefaults are the values the broker fills in for attributes not given on creation
+var sempDefaults = map[string]map[string]interface{}{
+	"queues": {
+		"accessType":         "exclusive",
+		"deadMsgQueue":       "#DEAD_MSG_QUEUE",
+		"egressEnabled":      false,
+		"ingressEnabled":     false,
+		"maxMsgSpoolUsage":   5000,
+		"maxRedeliveryCount": 0,
+		"owner":              "",
+		"permission":         "no-access",
+	},
+	"aclProfiles": {
+		"clientConnectDefaultAction":  "allow",
+		"publishTopicDefaultAction":   "disallow",
+		"subscribeTopicDefaultAction": "disallow",
+	},
+	"clientUsernames": {
+		"aclProfileName":    "default",
+		"clientProfileName": "default",
+		"enabled":           false,
+	},
+}
+
+// sempWriteOnly are attributes which are never returned by the broker
+var sempWriteOnly = []string{"password"}
+
+// newSempObjects returns the objects every message VPN has
+func newSempObjects(msgVpnName string) map[string]map[string]interface{} {
+	vpnPath := "msgVpns/" + url.PathEscape(msgVpnName)
+	return map[string]map[string]interface{}{
+		vpnPath: {
+			"msgVpnName":         msgVpnName,
+			"enabled":            true,
+			"maxConnectionCount": 100,
+			"maxMsgSpoolUsage":   1500,
+		},
+		vpnPath + "/aclProfiles/default": {
+			"msgVpnName":                  msgVpnName,
+			"aclProfileName":              "default",
+			"clientConnectDefaultAction":  "allow",
+			"publishTopicDefaultAction":   "allow",
+			"subscribeTopicDefaultAction": "allow",
+		},
+		vpnPath + "/clientUsernames/default": {
+			"msgVpnName":        msgVpnName,
+			"clientUsername":    "default",
+			"aclProfileName":    "default",
+			"clientProfileName": "default",
+			"enabled":           false,
+		},
+	}
+}
+
+// sempIdentifier returns the identifying attribute of a collection
+func sempIdentifier(collection string) string {
+	if id, ok := sempIdentifiers[collection]; ok {
+		return id
+	}
+	return strings.TrimSuffix(collection, "s") + "Name"
+}
+
+// handleSemp handles .../eventBrokerServices/{sid}/broker/SEMP/v2/config/{resourcePath}
+func (svr *Fakeserver) handleSemp(w http.ResponseWriter, r *http.Request, sInfo *ServiceInfo, parts []string, body []byte) {
+	segments := parts[10:]
+	if len(segments) == 0 || slices.Contains(segments, "") {
+		svr.writeSempError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid path")
+		return
+	}
+	resourcePath := strings.Join(segments, "/")
+	var jObj map[string]interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &jObj); err != nil {
+			svr.writeSempError(w, http.StatusBadRequest, "INVALID_PARAMETER", fmt.Sprintf("Invalid json: %s", err))
+			return
+		}
+	}
+	collection := len(segments)%2 == 1
+	objects := sInfo.SempObjects
+
+	if collection {
+		// the parent object has to exist
+		if len(segments) > 1 && objects[strings.Join(segments[:len(segments)-1], "/")] == nil {
+			svr.writeSempError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Could not find match for %s", resourcePath))
+			return
+		}
+		switch r.Method {
+		case "GET":
+			svr.handleSempList(w, r, objects, resourcePath)
+		case "POST":
+			collectionName := segments[len(segments)-1]
+			var ids []string
+			for _, idKey := range strings.Split(sempIdentifier(collectionName), ",") {
+				id, ok := jObj[idKey].(string)
+				if !ok || id == "" {
+					svr.writeSempError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", fmt.Sprintf("Missing attribute %s", idKey))
+					return
+				}
+				ids = append(ids, url.PathEscape(id))
+			}
+			objectPath := resourcePath + "/" + strings.Join(ids, ",")
+			if objects[objectPath] != nil {
+				svr.writeSempError(w, http.StatusBadRequest, "ALREADY_EXISTS", fmt.Sprintf("Object %s already exists", objectPath))
+				return
+			}
+			obj := maps.Clone(sempDefaults[collectionName])
+			if obj == nil {
+				obj = map[string]interface{}{}
+			}
+			maps.Copy(obj, jObj)
+			objects[objectPath] = obj
+			svr.writeSempData(w, obj)
+		default:
+			svr.writeSempError(w, http.StatusBadRequest, "NOT_SUPPORTED", fmt.Sprintf("%s is not supported on %s", r.Method, resourcePath))
+		}
+		return
+	}
+
+	obj := objects[resourcePath]
+	if obj == nil {
+		svr.writeSempError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Could not find match for %s", resourcePath))
+		return
+	}
+	switch r.Method {
+	case "GET":
+		svr.writeSempData(w, obj)
+	case "PATCH":
+		maps.Copy(obj, jObj)
+		svr.writeSempData(w, obj)
+	case "PUT":
+		// attributes missing from the request are reset to their defaults, the identifiers stay
+		replaced := maps.Clone(sempDefaults[segments[len(segments)-2]])
+		if replaced == nil {
+			replaced = map[string]interface{}{}
+		}
+		maps.Copy(replaced, jObj)
+		for i := 0; i < len(segments); i += 2 {
+			for _, idKey := range strings.Split(sempIdentifier(segments[i]), ",") {
+				if idValue, ok := obj[idKey]; ok {
+					replaced[idKey] = idValue
+				}
+			}
+		}
+		objects[resourcePath] = replaced
+		svr.writeSempData(w, replaced)
+	case "DELETE":
+		// children are removed along with the object
+		for p := range objects {
+			if p == resourcePath || strings.HasPrefix(p, resourcePath+"/") {
+				delete(objects, p)
+			}
+		}
+		svr.writeSempData(w, nil)
+	default:
+		svr.writeSempError(w, http.StatusBadRequest, "NOT_SUPPORTED", fmt.Sprintf("%s is not supported on %s", r.Method, resourcePath))
+	}
+}
+
+// handleSempList returns the objects of a collection, paged by count and cursor
+func (svr *Fakeserver) handleSempList(w http.ResponseWriter, r *http.Request, objects map[string]map[string]interface{}, collectionPath string) {
+	var paths []string
+	for p := range objects {
+		if rest, ok := strings.CutPrefix(p, collectionPath+"/"); ok && !strings.Contains(rest, "/") {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 1 {
+		count = 10
+	}
+	cursor, err := strconv.Atoi(r.URL.Query().Get("cursor"))
+	if err != nil || cursor < 0 {
+		cursor = 0
+	}
+	start := min(cursor, len(paths))
+	end := min(start+count, len(paths))
+	data := []interface{}{}
+	for _, p := range paths[start:end] {
+		data = append(data, sempReadable(objects[p]))
+	}
+	meta := map[string]interface{}{
+		"responseCode": 200,
+	}
+	if end < len(paths) {
+		query := url.Values{"count": {strconv.Itoa(count)}, "cursor": {strconv.Itoa(end)}}
+		meta["paging"] = map[string]interface{}{
+			"cursorQuery": strconv.Itoa(end),
+			"nextPageUri": fmt.Sprintf("http://%s%s?%s", r.Host, r.URL.EscapedPath(), query.Encode()),
+		}
+	}
+	svr.writeJSON(w, 200, map[string]interface{}{"data": data, "meta": meta})
+}
+
+// sempReadable returns the object without its write-only attributes
+func sempReadable(obj map[string]interface{}) map[string]interface{} {
+	readable := maps.Clone(obj)
+	for _, k := range sempWriteOnly {
+		delete(readable, k)
+	}
+	return readable
+}
+
+// writeSempData writes a SEMP response with the object as data
+func (svr *Fakeserver) writeSempData(w http.ResponseWriter, obj map[string]interface{}) {
+	result := map[string]interface{}{
+		"meta": map[string]interface{}{
+			"responseCode": 200,
+		},
+	}
+	if obj != nil {
+		result["data"] = sempReadable(obj)
+	}
+	svr.writeJSON(w, 200, result)
+}
+
+// writeSempError writes a SEMP error response
+func (svr *Fakeserver) writeSempError(w http.ResponseWriter, statusCode int, status string, description string) {
+	svr.writeJSON(w, statusCode, map[string]interface{}{
+		"meta": map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":        6,
+				"description": description,
+				"status":      status,
+			},
+			"responseCode": statusCode,
+		},
+	})
+}
+
+// writeJSON writes the given object as json
+func (svr *Fakeserver) writeJSON(w http.ResponseWriter, statusCode int, result interface{}) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("fakeserver: failed to marshal result: %s\n", err)
+		return
+	}
+	if svr.debug {
+		log.Printf("fakeserver: BODY %s", string(b))
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(b); err != nil {
+		log.Printf("fakeserver: failed to write result: %s\n", err)
+	}
+}
@@ -0,0 +1,143 @@
+package fakeserver
+
+import (
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServerCertificateInfo is an uploaded server certificate, the private key is not kept
+type ServerCertificateInfo struct {
+	ID             string
+	Installed      bool
+	SubjectCN      string
+	SerialNumber   string
+	Sha1Thumbprint string
+	NotBefore      time.Time
+	NotAfter       time.Time
+}
+
+// handleServerCertificates handles .../eventBrokerServices/{sid}/serverCertificates[/{cid}[/install]]
+func (svr *Fakeserver) handleServerCertificates(w http.ResponseWriter, method string, sInfo *ServiceInfo, parts []string, body []byte) {
+	if len(parts) == 7 {
+		switch method {
+		case "GET":
+			summaries := []interface{}{}
+			for _, c := range sInfo.ServerCertificates {
+				summaries = append(summaries, map[string]interface{}{
+					"id":              c.ID,
+					"installed":       c.Installed,
+					"certificateType": "CUSTOM",
+					"type":            "serverCertificate",
+				})
+			}
+			svr.writeData(w, 200, summaries)
+		case "POST":
+			svr.handleUploadServerCertificate(w, sInfo, body)
+		default:
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		return
+	}
+	idx := serverCertificateIndex(sInfo, parts[7])
+	if idx < 0 {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find server certificate with id %s\",\"errorId\":\"42\"}", parts[7]), http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 8 && method == "GET":
+		svr.writeData(w, 200, serverCertificateJSON(sInfo.ServerCertificates[idx]))
+	case len(parts) == 8 && method == "DELETE":
+		// the certificate is removed immediately, the operation completes later
+		certificateId := sInfo.ServerCertificates[idx].ID
+		sInfo.ServerCertificates = append(sInfo.ServerCertificates[:idx:idx], sInfo.ServerCertificates[idx+1:]...)
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, certificateId, "deleteServerCertificate"))
+	case len(parts) == 9 && parts[8] == "install" && method == "POST":
+		// certificates for subjects starting with "fail" can't be installed
+		if strings.HasPrefix(sInfo.ServerCertificates[idx].SubjectCN, "fail") {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Server certificate %s can't be installed\",\"errorId\":\"42\"}", parts[7]), http.StatusBadRequest)
+			return
+		}
+		// only one certificate is installed at a time
+		for i := range sInfo.ServerCertificates {
+			sInfo.ServerCertificates[i].Installed = i == idx
+		}
+		svr.objects[sInfo.ID] = *sInfo
+		svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, sInfo.ServerCertificates[idx].ID, "installServerCertificate"))
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+func (svr *Fakeserver) handleUploadServerCertificate(w http.ResponseWriter, sInfo *ServiceInfo, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		http.Error(w, "{\"message\":\"Invalid request body\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	block, _ := pem.Decode([]byte(orDefault(jObj["certificate"], "")))
+	if block == nil || block.Type != "CERTIFICATE" {
+		http.Error(w, "{\"message\":\"The certificate is not a PEM encoded certificate\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Invalid certificate: %s\",\"errorId\":\"42\"}", err), http.StatusBadRequest)
+		return
+	}
+	if !strings.Contains(orDefault(jObj["privateKey"], ""), "PRIVATE KEY") {
+		http.Error(w, "{\"message\":\"The private key is not a PEM encoded private key\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	certificate := ServerCertificateInfo{
+		ID:             sInfo.ID + "-" + uuid.New().String(),
+		SubjectCN:      cert.Subject.CommonName,
+		SerialNumber:   cert.SerialNumber.Text(16),
+		Sha1Thumbprint: fmt.Sprintf("%X", sha1.Sum(block.Bytes)),
+		NotBefore:      cert.NotBefore,
+		NotAfter:       cert.NotAfter,
+	}
+	sInfo.ServerCertificates = append(sInfo.ServerCertificates, certificate)
+	svr.objects[sInfo.ID] = *sInfo
+	svr.writeOperation(w, 202, svr.newOperation(sInfo.ID, certificate.ID, "uploadServerCertificate"))
+}
+
+/*ServerCertificateCount returns the number of server certificates uploaded to a service*/
+func (svr *Fakeserver) ServerCertificateCount(sid string) int {
+	return len(svr.objects[sid].ServerCertificates)
+}
+
+func serverCertificateIndex(sInfo *ServiceInfo, certificateId string) int {
+	for i, c := range sInfo.ServerCertificates {
+		if c.ID == certificateId {
+			return i
+		}
+	}
+	return -1
+}
+
+func serverCertificateJSON(c ServerCertificateInfo) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":                c.ID,
+		"installed":         c.Installed,
+		"certificateType":   "CUSTOM",
+		"subjectCN":         c.SubjectCN,
+		"serialNumber":      c.SerialNumber,
+		"sha1Thumbprint":    c.Sha1Thumbprint,
+		"validityNotBefore": c.NotBefore.UTC().Format(time.RFC3339),
+		"validityNotAfter":  c.NotAfter.UTC().Format(time.RFC3339),
+		"type":              "serverCertificate",
+	}
+	if c.Installed {
+		result["installedCertificateDetails"] = fmt.Sprintf("Serial Number: %s\nSubject: CN=%s", c.SerialNumber, c.SubjectCN)
+	}
+	return result
+}
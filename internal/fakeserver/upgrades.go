@@ -0,0 +1,213 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// upgradeDuration is the time an upgrade is in progress after its scheduled start
+const upgradeDuration = 3 * time.Second
+
+// MaintenanceActivityInfo is an event broker upgrade, run right away or scheduled in a maintenance window
+type MaintenanceActivityInfo struct {
+	ID                     string
+	ServiceId              string
+	TargetVersion          string
+	MaintenanceWindowId    string
+	MaintenanceTimeOrdinal int32
+	Created                time.Time
+	ScheduledStart         time.Time
+	Cancelled              bool
+}
+
+// status of the activity, derived from its schedule
+func (a MaintenanceActivityInfo) status() string {
+	switch {
+	case a.Cancelled:
+		return "CANCELLED"
+	case time.Now().Before(a.ScheduledStart):
+		return "SCHEDULED"
+	case time.Now().Before(a.ScheduledStart.Add(upgradeDuration)):
+		return "IN_PROGRESS"
+	default:
+		return "COMPLETED"
+	}
+}
+
+// handleUpgradeReadiness handles GET .../eventBrokerServices/{sid}/upgradeReadiness
+func (svr *Fakeserver) handleUpgradeReadiness(w http.ResponseWriter, sInfo *ServiceInfo) {
+	svr.progressCreation(sInfo, sInfo.ID)
+	svr.completeUpgrades(sInfo)
+	readiness := map[string]interface{}{"status": "OK", "message": "The event broker service is ready for an upgrade"}
+	if sInfo.State != "COMPLETED" {
+		readiness = map[string]interface{}{"status": "UNAVAILABLE", "message": fmt.Sprintf("The event broker service is %s", sInfo.State)}
+	} else if svr.upgradeInProgress(sInfo.ID) {
+		readiness = map[string]interface{}{"status": "UNAVAILABLE", "message": "An upgrade of the event broker service is in progress"}
+	}
+	svr.writeData(w, 200, readiness)
+}
+
+// handleUpgrades handles .../eventBrokerServices/{sid}/upgrades[/{activityId}]
+func (svr *Fakeserver) handleUpgrades(w http.ResponseWriter, method string, sInfo *ServiceInfo, parts []string, body []byte) {
+	svr.completeUpgrades(sInfo)
+	switch {
+	case len(parts) == 7 && method == "POST":
+		svr.handleCreateUpgrade(w, sInfo, body)
+	case len(parts) == 7 && method == "GET":
+		activities := []interface{}{}
+		for _, a := range svr.activities {
+			if a.ServiceId == sInfo.ID {
+				activities = append(activities, svr.activityJSON(a))
+			}
+		}
+		svr.writeData(w, 200, activities)
+	case len(parts) == 8 && method == "GET":
+		// the api returns a list, empty if the activity does not exist
+		activities := []interface{}{}
+		if a, ok := svr.activities[parts[7]]; ok && a.ServiceId == sInfo.ID {
+			activities = append(activities, svr.activityJSON(a))
+		}
+		svr.writeData(w, 200, activities)
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+func (svr *Fakeserver) handleCreateUpgrade(w http.ResponseWriter, sInfo *ServiceInfo, body []byte) {
+	var jObj map[string]interface{}
+	if err := json.Unmarshal(body, &jObj); err != nil {
+		http.Error(w, "{\"message\":\"Invalid request body\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	targetVersion := orDefault(jObj["targetVersion"], "")
+	if compareVersions(targetVersion, sInfo.EventBrokerVersion) <= 0 {
+		http.Error(w, fmt.Sprintf("{\"message\":\"The target version %s must be newer than %s\",\"errorId\":\"42\"}", targetVersion, sInfo.EventBrokerVersion), http.StatusBadRequest)
+		return
+	}
+	if svr.upgradeInProgress(sInfo.ID) {
+		http.Error(w, "{\"message\":\"An upgrade of the event broker service is in progress\",\"errorId\":\"42\"}", http.StatusBadRequest)
+		return
+	}
+	activity := MaintenanceActivityInfo{
+		ID:             uuid.New().String(),
+		ServiceId:      sInfo.ID,
+		TargetVersion:  targetVersion,
+		Created:        time.Now(),
+		ScheduledStart: time.Now(),
+	}
+	// upgrades in a maintenance window are scheduled an hour ahead
+	if windowId, ok := jObj["maintenanceWindowId"].(string); ok && windowId != "" {
+		activity.MaintenanceWindowId = windowId
+		if ordinal, ok := jObj["maintenanceTimeOrdinal"].(float64); ok {
+			activity.MaintenanceTimeOrdinal = int32(ordinal)
+		}
+		activity.ScheduledStart = time.Now().Add(time.Hour)
+	}
+	svr.activities[activity.ID] = activity
+	if svr.debug {
+		log.Printf("fakeserver: upgrade %s of service %s to %s", activity.ID, sInfo.ID, targetVersion)
+	}
+	svr.writeJSON(w, 201, map[string]interface{}{"data": svr.activityJSON(activity)})
+}
+
+// completeUpgrades applies the target version of completed upgrades
+func (svr *Fakeserver) completeUpgrades(sInfo *ServiceInfo) {
+	for _, a := range svr.activities {
+		if a.ServiceId == sInfo.ID && a.status() == "COMPLETED" && compareVersions(a.TargetVersion, sInfo.EventBrokerVersion) > 0 {
+			sInfo.EventBrokerVersion = a.TargetVersion
+			sInfo.Updated = time.Now()
+			svr.objects[sInfo.ID] = *sInfo
+		}
+	}
+}
+
+func (svr *Fakeserver) upgradeInProgress(sid string) bool {
+	for _, a := range svr.activities {
+		if a.ServiceId == sid && a.status() == "IN_PROGRESS" {
+			return true
+		}
+	}
+	return false
+}
+
+func (svr *Fakeserver) activityJSON(a MaintenanceActivityInfo) map[string]interface{} {
+	status := a.status()
+	result := map[string]interface{}{
+		"id":                        a.ID,
+		"activityType":              "SERVICE_UPGRADE",
+		"resourceId":                a.ServiceId,
+		"resourceType":              "SOLACE_EVENT_BROKER",
+		"maintenanceActivityStatus": status,
+		"operationStatus":           status,
+		"description":               fmt.Sprintf("Upgrade to %s", a.TargetVersion),
+		"createdTime":               a.Created.UTC().Format(time.RFC3339),
+		"scheduledStartTime":        a.ScheduledStart.UTC().Format(time.RFC3339),
+		"scheduledEndTime":          a.ScheduledStart.Add(upgradeDuration).UTC().Format(time.RFC3339),
+		"type":                      "maintenanceActivity",
+	}
+	if a.MaintenanceWindowId != "" {
+		result["maintenanceWindowId"] = a.MaintenanceWindowId
+	}
+	return result
+}
+
+// compareVersions compares dotted versions like 10.8.1.152 numerically, returns -1, 0 or 1
+func compareVersions(a string, b string) int {
+	as := strings.FieldsFunc(a, func(r rune) bool { return r == '.' || r == '-' })
+	bs := strings.FieldsFunc(b, func(r rune) bool { return r == '.' || r == '-' })
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// handleMaintenanceActivities handles .../maintenanceActivities/{id}[/cancel]
+func (svr *Fakeserver) handleMaintenanceActivities(w http.ResponseWriter, method string, parts []string) {
+	activity, ok := svr.activities[parts[5]]
+	if !ok {
+		http.Error(w, fmt.Sprintf("{\"message\":\"Could not find maintenance activity with id %s\",\"errorId\":\"42\"}", parts[5]), http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 6 && method == "GET":
+		svr.writeJSON(w, 200, map[string]interface{}{"data": svr.activityJSON(activity)})
+	case len(parts) == 7 && parts[6] == "cancel" && method == "POST":
+		// only activities which have not started yet can be cancelled
+		if activity.status() != "SCHEDULED" {
+			http.Error(w, fmt.Sprintf("{\"message\":\"Maintenance activity %s is %s and can't be cancelled\",\"errorId\":\"42\"}", activity.ID, activity.status()), http.StatusConflict)
+			return
+		}
+		activity.Cancelled = true
+		svr.activities[activity.ID] = activity
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}
+}
+
+/*StartUpgrade lets a scheduled upgrade start right away*/
+func (svr *Fakeserver) StartUpgrade(activityId string) {
+	if activity, ok := svr.activities[activityId]; ok {
+		activity.ScheduledStart = time.Now()
+		svr.activities[activityId] = activity
+	}
+}
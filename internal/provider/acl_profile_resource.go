@@ -0,0 +1,432 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// aclTopicSyntax is the topic syntax of the managed exceptions, MQTT exceptions are left alone
+const aclTopicSyntax = "smf"
+
+// aclProfileResourceModel maps the resource schema data.
+type aclProfileResourceModel struct {
+	ID                          types.String `tfsdk:"id"`
+	ServiceId                   types.String `tfsdk:"service_id"`
+	MsgVpnName                  types.String `tfsdk:"msg_vpn_name"`
+	AclProfileName              types.String `tfsdk:"acl_profile_name"`
+	ClientConnectDefaultAction  types.String `tfsdk:"client_connect_default_action"`
+	PublishTopicDefaultAction   types.String `tfsdk:"publish_topic_default_action"`
+	SubscribeTopicDefaultAction types.String `tfsdk:"subscribe_topic_default_action"`
+	PublishTopicExceptions      types.Set    `tfsdk:"publish_topic_exceptions"`
+	SubscribeTopicExceptions    types.Set    `tfsdk:"subscribe_topic_exceptions"`
+}
+
+// sempAclProfile is the SEMPv2 representation of an ACL profile
+type sempAclProfile struct {
+	MsgVpnName                  string  `json:"msgVpnName,omitempty"`
+	AclProfileName              string  `json:"aclProfileName,omitempty"`
+	ClientConnectDefaultAction  *string `json:"clientConnectDefaultAction,omitempty"`
+	PublishTopicDefaultAction   *string `json:"publishTopicDefaultAction,omitempty"`
+	SubscribeTopicDefaultAction *string `json:"subscribeTopicDefaultAction,omitempty"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &aclProfileResource{}
+	_ resource.ResourceWithConfigure   = &aclProfileResource{}
+	_ resource.ResourceWithImportState = &aclProfileResource{}
+)
+
+// NewAclProfileResource is a helper function to simplify the provider implementation.
+func NewAclProfileResource() resource.Resource {
+	return &aclProfileResource{}
+}
+
+// aclProfileResource is the resource implementation.
+type aclProfileResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *aclProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_profile"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aclProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure acl profile resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// helper returning the schema of an allow/disallow default action
+func aclDefaultActionAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + ": allow or disallow",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf("allow", "disallow"),
+		},
+	}
+}
+
+// helper returning the schema of a set of topic exceptions
+func aclTopicExceptionsAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: description + ", using SMF topic syntax. Each exception is added or removed individually",
+		ElementType:         types.StringType,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.UseStateForUnknown(),
+		},
+		Validators: []validator.Set{
+			setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 250)),
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *aclProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ACL profile of a broker service, managed through the mission control SEMP proxy. Settings which are not configured keep the value of the broker. Import using *service_id/msg_vpn_name/acl_profile_name*",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"msg_vpn_name": schema.StringAttribute{
+				MarkdownDescription: "The message VPN of the broker service, see *msg_vpn_name* of the broker",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"acl_profile_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the ACL profile",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"client_connect_default_action":  aclDefaultActionAttribute("The default action for client connections"),
+			"publish_topic_default_action":   aclDefaultActionAttribute("The default action for publishing to a topic"),
+			"subscribe_topic_default_action": aclDefaultActionAttribute("The default action for subscribing to a topic"),
+			"publish_topic_exceptions":       aclTopicExceptionsAttribute("The topics for which the publish default action is reversed"),
+			"subscribe_topic_exceptions":     aclTopicExceptionsAttribute("The topics for which the subscribe default action is reversed"),
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "service_id/msg_vpn_name/acl_profile_name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *aclProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState aclProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := aclProfileSettings(plannedState, nil)
+	body.MsgVpnName = plannedState.MsgVpnName.ValueString()
+	body.AclProfileName = plannedState.AclProfileName.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Creating acl profile %s", body.AclProfileName))
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPost, plannedState.ServiceId.ValueString(), "msgVpns/"+url.PathEscape(body.MsgVpnName)+"/aclProfiles", body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating acl profile",
+			"Could not create acl profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error creating acl profile",
+			sempResp.errorMessage(),
+		)
+		return
+	}
+
+	// store the keys right away, so failing exceptions leave a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), plannedState.ServiceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("msg_vpn_name"), plannedState.MsgVpnName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("acl_profile_name"), plannedState.AclProfileName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcileExceptions(ctx, plannedState, "publish", plannedState.PublishTopicExceptions, types.SetNull(types.StringType), &resp.Diagnostics)
+	r.reconcileExceptions(ctx, plannedState, "subscribe", plannedState.SubscribeTopicExceptions, types.SetNull(types.StringType), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error creating acl profile",
+			fmt.Sprintf("ACL profile %s vanished", plannedState.AclProfileName.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *aclProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState aclProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *aclProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState aclProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only send what has changed
+	body := aclProfileSettings(plannedState, &currentState)
+	if body != (sempAclProfile{}) {
+		tflog.Info(ctx, fmt.Sprintf("Updating acl profile %s using %v", plannedState.AclProfileName.ValueString(), body))
+		sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPatch, plannedState.ServiceId.ValueString(), aclProfilePath(plannedState), body, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating acl profile",
+				"Could not update acl profile, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !sempResp.ok() {
+			resp.Diagnostics.AddError(
+				"Error updating acl profile",
+				sempResp.errorMessage(),
+			)
+			return
+		}
+	}
+
+	r.reconcileExceptions(ctx, plannedState, "publish", plannedState.PublishTopicExceptions, currentState.PublishTopicExceptions, &resp.Diagnostics)
+	r.reconcileExceptions(ctx, plannedState, "subscribe", plannedState.SubscribeTopicExceptions, currentState.SubscribeTopicExceptions, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error updating acl profile",
+			fmt.Sprintf("ACL profile %s vanished", plannedState.AclProfileName.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *aclProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState aclProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the exceptions are removed along with the profile
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodDelete, currentState.ServiceId.ValueString(), aclProfilePath(currentState), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting acl profile",
+			"Could not delete acl profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if sempResp.notFound() {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find acl profile %s", currentState.AclProfileName.ValueString()))
+		// this is tolerable!
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error deleting acl profile",
+			sempResp.errorMessage(),
+		)
+	}
+}
+
+// ImportState imports an ACL profile using service_id/msg_vpn_name/acl_profile_name.
+func (r *aclProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.SplitN(req.ID, "/", 3)
+	if len(ids) != 3 || slices.Contains(ids, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/msg_vpn_name/acl_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("msg_vpn_name"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("acl_profile_name"), ids[2])...)
+}
+
+// helper adding the planned publish or subscribe exceptions missing in prior and removing the ones no longer planned
+func (r *aclProfileResource) reconcileExceptions(ctx context.Context, model aclProfileResourceModel, action string, plan types.Set, prior types.Set, diagnostics *diag.Diagnostics) {
+	if diagnostics.HasError() || plan.IsUnknown() || plan.IsNull() {
+		return
+	}
+	var planned, current []string
+	diagnostics.Append(plan.ElementsAs(ctx, &planned, false)...)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diagnostics.Append(prior.ElementsAs(ctx, &current, false)...)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	// exceptions are identified by syntax and topic
+	sempReconcile(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, model.ServiceId.ValueString(), aclProfilePath(model)+"/"+action+"TopicExceptions", planned, current,
+		func(topic string) string {
+			return aclTopicSyntax + "," + url.PathEscape(topic)
+		},
+		func(topic string) map[string]string {
+			return map[string]string{
+				"msgVpnName":                    model.MsgVpnName.ValueString(),
+				"aclProfileName":                model.AclProfileName.ValueString(),
+				action + "TopicException":       topic,
+				action + "TopicExceptionSyntax": aclTopicSyntax,
+			}
+		},
+		fmt.Sprintf("Error updating acl profile %s topic exceptions", action), diagnostics)
+}
+
+// helper reading the SMF topic exceptions of the profile for publish or subscribe
+func (r *aclProfileResource) getExceptions(ctx context.Context, model aclProfileResourceModel, action string, diagnostics *diag.Diagnostics) (types.Set, bool) {
+	exceptions, found := sempList(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, model.ServiceId.ValueString(), aclProfilePath(model)+"/"+action+"TopicExceptions", nil,
+		fmt.Sprintf("Error getting acl profile %s topic exceptions", action), diagnostics)
+	if diagnostics.HasError() || !found {
+		return types.SetNull(types.StringType), false
+	}
+	exceptions = slices.DeleteFunc(exceptions, func(e map[string]interface{}) bool {
+		return e[action+"TopicExceptionSyntax"] != aclTopicSyntax
+	})
+	topics, diags := types.SetValueFrom(ctx, types.StringType, sempStrings(exceptions, action+"TopicException"))
+	diagnostics.Append(diags...)
+	return topics, !diagnostics.HasError()
+}
+
+// helper reading the profile and its exceptions into the model, returns false if it does not exist
+func (r *aclProfileResource) get(ctx context.Context, model *aclProfileResourceModel, diagnostics *diag.Diagnostics) bool {
+	serviceId := model.ServiceId.ValueString()
+	var profile sempAclProfile
+	found := sempGetObject(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, aclProfilePath(*model), &profile, "Error getting acl profile", diagnostics)
+	if diagnostics.HasError() || !found {
+		return false
+	}
+	publishExceptions, found := r.getExceptions(ctx, *model, "publish", diagnostics)
+	if !found {
+		return false
+	}
+	subscribeExceptions, found := r.getExceptions(ctx, *model, "subscribe", diagnostics)
+	if !found {
+		return false
+	}
+
+	model.ID = types.StringValue(serviceId + "/" + model.MsgVpnName.ValueString() + "/" + model.AclProfileName.ValueString())
+	model.ClientConnectDefaultAction = types.StringPointerValue(profile.ClientConnectDefaultAction)
+	model.PublishTopicDefaultAction = types.StringPointerValue(profile.PublishTopicDefaultAction)
+	model.SubscribeTopicDefaultAction = types.StringPointerValue(profile.SubscribeTopicDefaultAction)
+	model.PublishTopicExceptions = publishExceptions
+	model.SubscribeTopicExceptions = subscribeExceptions
+	return true
+}
+
+// helper returning the SEMP path of the ACL profile
+func aclProfilePath(model aclProfileResourceModel) string {
+	return "msgVpns/" + url.PathEscape(model.MsgVpnName.ValueString()) + "/aclProfiles/" + url.PathEscape(model.AclProfileName.ValueString())
+}
+
+// helper collecting the known settings of the plan. If a prior state is given, only the changed settings are collected
+func aclProfileSettings(plan aclProfileResourceModel, prior *aclProfileResourceModel) sempAclProfile {
+	var settings sempAclProfile
+	var current aclProfileResourceModel
+	if prior != nil {
+		current = *prior
+	}
+	// unknown values are not configured and taken from the broker
+	if known(plan.ClientConnectDefaultAction) && (prior == nil || !plan.ClientConnectDefaultAction.Equal(current.ClientConnectDefaultAction)) {
+		settings.ClientConnectDefaultAction = plan.ClientConnectDefaultAction.ValueStringPointer()
+	}
+	if known(plan.PublishTopicDefaultAction) && (prior == nil || !plan.PublishTopicDefaultAction.Equal(current.PublishTopicDefaultAction)) {
+		settings.PublishTopicDefaultAction = plan.PublishTopicDefaultAction.ValueStringPointer()
+	}
+	if known(plan.SubscribeTopicDefaultAction) && (prior == nil || !plan.SubscribeTopicDefaultAction.Equal(current.SubscribeTopicDefaultAction)) {
+		settings.SubscribeTopicDefaultAction = plan.SubscribeTopicDefaultAction.ValueStringPointer()
+	}
+	return settings
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAclProfileResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// unknown actions are rejected
+			{
+				Config:      testAclProfileConfig("a1", "ocs-prov-a1", "deny", `["orders/>"]`),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// Create and Read testing, settings not configured are taken from the broker
+			{
+				Config: testAclProfileConfig("a1", "ocs-prov-a1", "disallow", `["orders/>", "returns/>"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_acl_profile.a1",
+						tfjsonpath.New("client_connect_default_action"),
+						knownvalue.StringExact("allow"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_acl_profile.a1",
+						tfjsonpath.New("publish_topic_exceptions"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("orders/>"), knownvalue.StringExact("returns/>")}),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_acl_profile.a1",
+						tfjsonpath.New("subscribe_topic_exceptions"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("orders/>")}),
+					),
+				},
+			},
+			// the defaults of the broker do not cause diffs
+			{
+				Config: testAclProfileConfig("a1", "ocs-prov-a1", "disallow", `["orders/>", "returns/>"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "gsolaceclustermgr_acl_profile.a1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update in place, exceptions are reconciled one by one
+			{
+				Config: testAclProfileConfig("a1", "ocs-prov-a1", "allow", `["orders/>", "invoices/>"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_acl_profile.a1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_acl_profile.a1",
+						tfjsonpath.New("publish_topic_default_action"),
+						knownvalue.StringExact("allow"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_acl_profile.a1",
+						tfjsonpath.New("publish_topic_exceptions"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("orders/>"), knownvalue.StringExact("invoices/>")}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAclProfileConfig(rname string, brokerName string, publishAction string, publishExceptions string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_acl_profile" "%[1]s" {
+		service_id                   = gsolaceclustermgr_broker.%[1]s.id
+		msg_vpn_name                 = gsolaceclustermgr_broker.%[1]s.msg_vpn_name
+		acl_profile_name             = "orders-app"
+		publish_topic_default_action = "%[3]s"
+		publish_topic_exceptions     = %[4]s
+		subscribe_topic_exceptions   = ["orders/>"]
+	}
+	`, rname, brokerName, publishAction, publishExceptions)
+}
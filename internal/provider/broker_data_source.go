@@ -3,8 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"net/http/httputil"
 	"strings"
 	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
 	"time"
@@ -35,6 +33,11 @@ type brokerDataSourceModel struct {
 	MgmtAdminPassword      types.String `tfsdk:"admin_password"`
 	HostNames              types.List   `tfsdk:"hostnames"`
 	ServiceEndpointId      types.String `tfsdk:"service_endpoint_id"`
+	Locked                 types.Bool   `tfsdk:"locked"`
+	EnvironmentId          types.String `tfsdk:"environment_id"`
+	OwnedBy                types.String `tfsdk:"owned_by"`
+	RedundancyGroupSsl     types.Bool   `tfsdk:"redundancy_group_ssl_enabled"`
+	AllConnectionEndpoints types.List   `tfsdk:"service_connection_endpoints"`
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -43,18 +46,6 @@ var (
 	_ datasource.DataSourceWithConfigure = &brokerDataSource{}
 )
 
-// helper func to add bearer token auth header to requests
-func (d *brokerDataSource) BearerReqEditorFn(ctx context.Context, req *http.Request) error {
-	req.Header.Set("Authorization", "Bearer "+d.cMProviderData.BearerToken)
-	dump, err := httputil.DumpRequestOut(req, true)
-	if err != nil {
-		tflog.Error(ctx, err.Error())
-	} else {
-		tflog.Debug(ctx, fmt.Sprintf("Request: %s", dump))
-	}
-	return nil
-}
-
 // NewCoffeesDataSource is a helper function to simplify the provider implementation.
 func NewBrokerDataSource() datasource.DataSource {
 	return &brokerDataSource{}
@@ -105,6 +96,21 @@ func (d *brokerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed: true,
 			},
 
+			"environment_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"owned_by": schema.StringAttribute{
+				MarkdownDescription: "The user id of the broker owner",
+				Computed:            true,
+			},
+			"redundancy_group_ssl_enabled": schema.BoolAttribute{
+				MarkdownDescription: "SSL for the mate-link encryption between the HA nodes",
+				Computed:            true,
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Deletion protection",
+				Computed:            true,
+			},
 			"created": schema.StringAttribute{
 				Computed: true,
 			},
@@ -121,6 +127,42 @@ func (d *brokerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			"service_endpoint_id": schema.StringAttribute{
 				Computed: true,
 			},
+			"service_connection_endpoints": schema.ListNestedAttribute{
+				MarkdownDescription: "All service connection endpoints of the broker",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"access_type": schema.StringAttribute{
+							MarkdownDescription: "PUBLIC or PRIVATE",
+							Computed:            true,
+						},
+						"hostnames": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"ports": schema.MapNestedAttribute{
+							MarkdownDescription: "The ports by protocol, e.g. serviceSmfTlsListenPort",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"port": schema.Int32Attribute{
+										Computed: true,
+									},
+									"enabled": schema.BoolAttribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"missioncontrol_username": schema.StringAttribute{
 				Computed:  true,
 				Sensitive: true,
@@ -166,7 +208,7 @@ func (d *brokerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Get broker info
-	getResp, err := d.cMProviderData.Client.GetServiceWithResponse(ctx, queryID.ValueString(), &getParams, d.BearerReqEditorFn)
+	getResp, err := d.cMProviderData.Client.GetServiceWithResponse(ctx, queryID.ValueString(), &getParams, d.cMProviderData.BearerReqEditorFn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting broker service info",
@@ -174,7 +216,7 @@ func (d *brokerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", getResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
 	if getResp.StatusCode() == 401 {
 		var errMsg string
 		if getResp.JSON401 == nil {
@@ -217,10 +259,12 @@ func (d *brokerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// map to response state
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", getResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
 	currentState.ID = types.StringPointerValue(getResp.JSON200.Data.Id)
 	currentState.ServiceClassId = types.StringPointerValue((*string)(getResp.JSON200.Data.ServiceClassId))
 	currentState.DataCenterId = types.StringPointerValue(getResp.JSON200.Data.DatacenterId)
+	currentState.EnvironmentId = types.StringPointerValue(getResp.JSON200.Data.EnvironmentId)
+	currentState.OwnedBy = types.StringPointerValue(getResp.JSON200.Data.OwnedBy)
 	currentState.EventBrokerVersion = types.StringValue(getResp.JSON200.Data.EventBrokerServiceVersion)
 	if getResp.JSON200.Data.CreatedTime != nil {
 		currentState.Created = types.StringValue(getResp.JSON200.Data.CreatedTime.Format(time.RFC850))
@@ -233,12 +277,14 @@ func (d *brokerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		currentState.LastUpdated = types.StringValue("")
 	}
 	currentState.Status = types.StringValue(string(*(getResp.JSON200.Data.CreationState)))
+	currentState.Locked = types.BoolValue(getResp.JSON200.Data.Locked != nil && *(getResp.JSON200.Data.Locked))
 	currentState.Name = types.StringPointerValue(getResp.JSON200.Data.Name)
 	currentState.ClusterName = types.StringPointerValue(getResp.JSON200.Data.Broker.Cluster.Name)
 	routerPrefix, _ := strings.CutSuffix(*(getResp.JSON200.Data.Broker.Cluster.PrimaryRouterName), "primary")
 	currentState.CustomRouterName = types.StringValue(routerPrefix)
 	currentState.MsgVpnName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MsgVpnName)
 	currentState.MaxSpoolUsage = types.Int32PointerValue(getResp.JSON200.Data.Broker.MaxSpoolUsage)
+	currentState.RedundancyGroupSsl = types.BoolValue(getResp.JSON200.Data.Broker.RedundancyGroupSslEnabled != nil && *getResp.JSON200.Data.Broker.RedundancyGroupSslEnabled)
 	currentState.MissionControlUserName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MissionControlManagerLoginCredential.Username)
 	currentState.MissionControlPassword = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MissionControlManagerLoginCredential.Password)
 	currentState.MgmtAdminUserName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].ManagementAdminLoginCredential.Username)
@@ -252,6 +298,12 @@ func (d *brokerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	// the flat attributes above only cover the first endpoint
+	currentState.AllConnectionEndpoints = connectionEndpointsValue(ctx, getResp.JSON200.Data.ServiceConnectionEndpoints, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Read Broker state %s %s %v", currentState.Name, currentState.Status.ValueString(), currentState.LastUpdated))
 
 	// Set state
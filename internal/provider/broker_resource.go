@@ -4,19 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"regexp"
 	"strings"
 	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -26,31 +30,50 @@ import (
 
 // brokerResourceModel maps the resource schema data.
 type brokerResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	DataCenterId           types.String `tfsdk:"datacenter_id"`
-	Name                   types.String `tfsdk:"name"`
-	ClusterName            types.String `tfsdk:"cluster_name"`
-	MsgVpnName             types.String `tfsdk:"msg_vpn_name"`
-	Created                types.String `tfsdk:"created"`
-	LastUpdated            types.String `tfsdk:"last_updated"`
-	Status                 types.String `tfsdk:"status"`
-	ServiceClassId         types.String `tfsdk:"serviceclass_id"`
-	CustomRouterName       types.String `tfsdk:"custom_router_name"`
-	EventBrokerVersion     types.String `tfsdk:"event_broker_version"`
-	MaxSpoolUsage          types.Int32  `tfsdk:"max_spool_usage"`
-	MissionControlUserName types.String `tfsdk:"missioncontrol_username"`
-	MissionControlPassword types.String `tfsdk:"missioncontrol_password"`
-	MgmtAdminUserName      types.String `tfsdk:"admin_username"`
-	MgmtAdminPassword      types.String `tfsdk:"admin_password"`
-	HostNames              types.List   `tfsdk:"hostnames"`
-	ServiceEndpointId      types.String `tfsdk:"service_endpoint_id"`
+	ID                     types.String   `tfsdk:"id"`
+	DataCenterId           types.String   `tfsdk:"datacenter_id"`
+	Name                   types.String   `tfsdk:"name"`
+	ClusterName            types.String   `tfsdk:"cluster_name"`
+	MsgVpnName             types.String   `tfsdk:"msg_vpn_name"`
+	Created                types.String   `tfsdk:"created"`
+	LastUpdated            types.String   `tfsdk:"last_updated"`
+	Status                 types.String   `tfsdk:"status"`
+	ServiceClassId         types.String   `tfsdk:"serviceclass_id"`
+	CustomRouterName       types.String   `tfsdk:"custom_router_name"`
+	EventBrokerVersion     types.String   `tfsdk:"event_broker_version"`
+	MaxSpoolUsage          types.Int32    `tfsdk:"max_spool_usage"`
+	MissionControlUserName types.String   `tfsdk:"missioncontrol_username"`
+	MissionControlPassword types.String   `tfsdk:"missioncontrol_password"`
+	MgmtAdminUserName      types.String   `tfsdk:"admin_username"`
+	MgmtAdminPassword      types.String   `tfsdk:"admin_password"`
+	HostNames              types.List     `tfsdk:"hostnames"`
+	ServiceEndpointId      types.String   `tfsdk:"service_endpoint_id"`
+	Locked                 types.Bool     `tfsdk:"locked"`
+	EnvironmentId          types.String   `tfsdk:"environment_id"`
+	OwnedBy                types.String   `tfsdk:"owned_by"`
+	RedundancyGroupSsl     types.Bool     `tfsdk:"redundancy_group_ssl_enabled"`
+	SempBasicAuthEnabled   types.Bool     `tfsdk:"semp_basic_auth_enabled"`
+	SempBasicAuthProbe     types.Bool     `tfsdk:"semp_basic_auth_probe"`
+	ConnectionEndpoints    types.List     `tfsdk:"service_connection_endpoint"`
+	AllConnectionEndpoints types.List     `tfsdk:"service_connection_endpoints"`
+	DeleteOnFailure        types.Bool     `tfsdk:"delete_on_failure"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+}
+
+// serviceConnectionEndpointModel maps a service_connection_endpoint block (used on creation only).
+type serviceConnectionEndpointModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	AccessType  types.String `tfsdk:"access_type"`
+	Ports       types.Map    `tfsdk:"ports"`
 }
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &brokerResource{}
-	_ resource.ResourceWithConfigure   = &brokerResource{}
-	_ resource.ResourceWithImportState = &brokerResource{}
+	_ resource.Resource                   = &brokerResource{}
+	_ resource.ResourceWithConfigure      = &brokerResource{}
+	_ resource.ResourceWithImportState    = &brokerResource{}
+	_ resource.ResourceWithValidateConfig = &brokerResource{}
 )
 
 // NewBrokerResource is a helper function to simplify the provider implementation.
@@ -58,18 +81,6 @@ func NewBrokerResource() resource.Resource {
 	return &brokerResource{}
 }
 
-// helper func to add bearer token auth header to requests
-func (r *brokerResource) BearerReqEditorFn(ctx context.Context, req *http.Request) error {
-	req.Header.Set("Authorization", "Bearer "+r.cMProviderData.BearerToken)
-	dump, err := httputil.DumpRequestOut(req, true)
-	if err != nil {
-		tflog.Error(ctx, err.Error())
-	} else {
-		tflog.Debug(ctx, fmt.Sprintf("Request: %s", dump))
-	}
-	return nil
-}
-
 // brokerResource is the resource implementation.
 type brokerResource struct {
 	cMProviderData CMProviderData
@@ -101,11 +112,30 @@ func (r *brokerResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.cMProviderData = cMProviderData
 }
 
+// ValidateConfig checks attribute combinations the schema cannot express.
+func (r *brokerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config brokerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// mate-link encryption is only available for HA brokers, disabling it is fine for any broker
+	if config.RedundancyGroupSsl.ValueBool() && !config.ServiceClassId.IsUnknown() &&
+		!strings.HasSuffix(config.ServiceClassId.ValueString(), "_HIGHAVAILABILITY") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("redundancy_group_ssl_enabled"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("redundancy_group_ssl_enabled is only supported for *_HIGHAVAILABILITY service classes, got serviceclass_id %s", config.ServiceClassId.ValueString()),
+		)
+	}
+}
+
 // Schema defines the schema for the resource.
 func (r *brokerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	tflog.Info(ctx, "define broker schema")
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Event Broker Resource. Note that *name* is the only attribute you can update without forcing a replacement",
+		MarkdownDescription: "Event Broker Resource. Note that *name*, *owned_by*, *locked*, *semp_basic_auth_enabled*, an increased *max_spool_usage* and a newer *event_broker_version* are the only attributes you can update without forcing a replacement",
 		Attributes: map[string]schema.Attribute{
 			// creation params
 			"name": schema.StringAttribute{
@@ -157,24 +187,80 @@ func (r *brokerResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"event_broker_version": schema.StringAttribute{
-				Computed: true,
-				Optional: true,
+				MarkdownDescription: "The event broker version. Changing it upgrades the broker in place after an upgrade readiness check, downgrades are rejected",
+				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+					versionDowngradeModifier{},
 				},
 			},
 			// figure out how to handle int32
 			"max_spool_usage": schema.Int32Attribute{
 				Computed:            true,
 				Optional:            true,
-				MarkdownDescription: "The message spool size, in gigabytes (GB)",
+				MarkdownDescription: "The message spool size, in gigabytes (GB). Increasing the spool size is done in place, decreasing it forces a replacement",
 				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.RequiresReplaceIfConfigured(),
+					int32planmodifier.RequiresReplaceIf(
+						requiresReplaceIfDecreased,
+						"Decreasing the message spool size requires replacing the broker",
+						"Decreasing the message spool size requires replacing the broker",
+					),
 				},
 				Validators: []validator.Int32{
 					int32validator.Between(10, 6000),
 				},
 			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "The environment of the broker (only supported in public regions, defaults to the default environment)",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owned_by": schema.StringAttribute{
+				MarkdownDescription: "The user id of the broker owner, defaults to the creator. Changing the owner is done in place",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"redundancy_group_ssl_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Enable SSL for the mate-link encryption between the HA nodes. Can only be enabled for *_HIGHAVAILABILITY service classes",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIfConfigured(),
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"semp_basic_auth_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether basic authentication is allowed for management access (SEMP), set after creation. Left as is if not configured. " +
+					"Mission control can't read the setting, so drift is only detected with *semp_basic_auth_probe*",
+				Optional: true,
+			},
+			"semp_basic_auth_probe": schema.BoolAttribute{
+				MarkdownDescription: "Detect drift of *semp_basic_auth_enabled* by probing the management endpoint with the admin credentials on every refresh. " +
+					"Only answers telling a disabled basic authentication apart from rejected credentials change the state. Defaults to false",
+				Optional: true,
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Deletion protection: a locked broker cannot be deleted (or replaced) until it has been unlocked by applying *locked = false*",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"delete_on_failure": schema.BoolAttribute{
+				MarkdownDescription: "Delete the broker service automatically when its creation fails (instead of keeping it as tainted resource). Defaults to false",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			//
 			// computed attributes
 			"id": schema.StringAttribute{
@@ -202,6 +288,45 @@ func (r *brokerResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 			"service_endpoint_id": schema.StringAttribute{
 				Computed: true,
 			},
+			"service_connection_endpoints": schema.ListNestedAttribute{
+				MarkdownDescription: "All service connection endpoints of the broker",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"access_type": schema.StringAttribute{
+							MarkdownDescription: "PUBLIC or PRIVATE",
+							Computed:            true,
+						},
+						"hostnames": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"ports": schema.MapNestedAttribute{
+							MarkdownDescription: "The ports by protocol, e.g. serviceSmfTlsListenPort",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"port": schema.Int32Attribute{
+										Computed: true,
+									},
+									"enabled": schema.BoolAttribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"missioncontrol_username": schema.StringAttribute{
 				Computed:  true,
 				Sensitive: true,
@@ -221,6 +346,47 @@ func (r *brokerResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				Sensitive:           true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+			"service_connection_endpoint": schema.ListNestedBlock{
+				MarkdownDescription: "Service connection endpoints created together with the broker (instead of the default endpoint). Changing them forces a replacement",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPreviouslySet,
+						"Service connection endpoints are only configured on creation",
+						"Service connection endpoints are only configured on creation",
+					),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the connection endpoint",
+							Required:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the connection endpoint",
+							Optional:            true,
+						},
+						"access_type": schema.StringAttribute{
+							MarkdownDescription: "The connectivity of the endpoint, PUBLIC or PRIVATE",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(missioncontrol.PUBLIC), string(missioncontrol.PRIVATE)),
+							},
+						},
+						"ports": schema.MapAttribute{
+							MarkdownDescription: "The port numbers by protocol, e.g. serviceSmfTlsListenPort = 55443. Use 0 to disable a port. serviceManagementTlsListenPort and serviceSmfTlsListenPort must be given",
+							ElementType:         types.Int32Type,
+							Required:            true,
+							Validators: []validator.Map{
+								mapvalidator.KeysAre(stringvalidator.OneOf(endpointProtocols...)),
+								mapvalidator.ValueInt32sAre(int32validator.Between(0, 65535)),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -235,23 +401,39 @@ func (r *brokerResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configuredOwner := plannedState.OwnedBy
+
+	connectionEndpoints := r.connectionEndpointsFromPlan(ctx, plannedState.ConnectionEndpoints, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	var body = missioncontrol.CreateServiceJSONRequestBody{
-		Name:               plannedState.Name.ValueString(),
-		ServiceClassId:     missioncontrol.ServiceClassId(plannedState.ServiceClassId.ValueString()),
-		DatacenterId:       plannedState.DataCenterId.ValueString(),
-		MsgVpnName:         nullIfEmptyStringPtr(plannedState.MsgVpnName),
-		ClusterName:        nullIfEmptyStringPtr(plannedState.ClusterName),
-		EventBrokerVersion: nullIfEmptyStringPtr(plannedState.EventBrokerVersion),
-		CustomRouterName:   nullIfEmptyStringPtr(plannedState.CustomRouterName),
-		MaxSpoolUsage:      nullIfEmptyInt32Ptr(plannedState.MaxSpoolUsage),
+		Name:                       plannedState.Name.ValueString(),
+		ServiceClassId:             missioncontrol.ServiceClassId(plannedState.ServiceClassId.ValueString()),
+		DatacenterId:               plannedState.DataCenterId.ValueString(),
+		MsgVpnName:                 nullIfEmptyStringPtr(plannedState.MsgVpnName),
+		ClusterName:                nullIfEmptyStringPtr(plannedState.ClusterName),
+		EventBrokerVersion:         nullIfEmptyStringPtr(plannedState.EventBrokerVersion),
+		CustomRouterName:           nullIfEmptyStringPtr(plannedState.CustomRouterName),
+		MaxSpoolUsage:              nullIfEmptyInt32Ptr(plannedState.MaxSpoolUsage),
+		Locked:                     nullIfEmptyBoolPtr(plannedState.Locked),
+		RedundancyGroupSslEnabled:  nullIfEmptyBoolPtr(plannedState.RedundancyGroupSsl),
+		ServiceConnectionEndpoints: connectionEndpoints,
+		EnvironmentId:              nullIfEmptyStringPtr(plannedState.EnvironmentId),
 	}
-	tflog.Info(ctx, fmt.Sprintf("Request: %s %s %v %s using %s", "Foo", body.Name, body.ServiceClassId, body.DatacenterId, plannedState.ServiceClassId.ValueString()))
 
 	// Use client to create new broker
-	tflog.Info(ctx, fmt.Sprintf("Creating broker service using %v", body))
+	tflog.Info(ctx, fmt.Sprintf("Creating broker service %s of class %s in datacenter %s", body.Name, body.ServiceClassId, body.DatacenterId))
 
-	createResp, err := r.cMProviderData.Client.CreateServiceWithResponse(ctx, body, r.BearerReqEditorFn)
+	createResp, err := r.cMProviderData.Client.CreateServiceWithResponse(ctx, body, r.cMProviderData.BearerReqEditorFn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating broker service",
@@ -260,7 +442,7 @@ func (r *brokerResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Response Header:%s", createResp.HTTPResponse.Header))
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", createResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(createResp.Body)))
 	// As of 20250324 the errorResponse is an application/xml error object, it will not be mapped to json"
 	if createResp.StatusCode() == 400 {
 		var errMsg string
@@ -331,36 +513,72 @@ func (r *brokerResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	resourceId := *(createResp.JSON202.Data.ResourceId)
+	operationId := *(createResp.JSON202.Data.Id)
+
+	// store the id right away, so a failed or timed out creation leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), resourceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), plannedState.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serviceclass_id"), plannedState.ServiceClassId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("datacenter_id"), plannedState.DataCenterId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_on_failure"), plannedState.DeleteOnFailure)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timeouts"), plannedState.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("Waiting for broker service using %s to finish creation", resourceId))
 
-	// TODO: polling GET with full expansion is maybe expensive - we could poll for the operation and fetch the full state once instead
+	// poll the lightweight operation and fetch the full (expanded) service state only once afterwards
+	op := waitForServiceOperation(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, resourceId, operationId, createTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if op == nil {
+		resp.Diagnostics.AddError(
+			"Error creating broker service",
+			fmt.Sprintf("Could not find creation operation %s of broker service %s", operationId, resourceId),
+		)
+		return
+	}
 
-	timeout := time.Now().Add(r.cMProviderData.PollingTimeoutDuration)
-	for created := false; !created; {
-		// sleep, timeout
-		if time.Now().After(timeout) {
-			resp.Diagnostics.AddError(
-				"Timeout",
-				"timeout creating broker service",
-			)
+	r.fullGet(ctx, resourceId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Broker status %s", plannedState.Status.ValueString()))
+
+	// FAILED or unexpected state
+	if *op.Status == missioncontrol.OperationStatusFAILED || plannedState.Status.ValueString() != string(missioncontrol.ServiceCreationStateCOMPLETED) {
+		r.handleFailedCreation(ctx, resourceId, operationId, &plannedState, resp)
+		return
+	}
+
+	// the owner cannot be passed on creation, transfer the ownership afterwards
+	if configuredOwner.ValueString() != "" && !configuredOwner.Equal(plannedState.OwnedBy) {
+		r.updateService(ctx, resourceId, missioncontrol.UpdateServiceJSONRequestBody{OwnedBy: configuredOwner.ValueStringPointer()}, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		time.Sleep(r.cMProviderData.PollingIntervalDuration)
-		tflog.Info(ctx, fmt.Sprintf("Checking broker status for %s", resourceId))
-
 		r.fullGet(ctx, resourceId, &plannedState, &resp.Diagnostics)
-
-		tflog.Info(ctx, fmt.Sprintf("Broker status %s", plannedState.Status.ValueString()))
-
 		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		if plannedState.Status.ValueString() == string(missioncontrol.ServiceCreationStateCOMPLETED) {
-			created = true
+	// basic auth can only be set on the running broker. A failure must not lose the broker, so the state is
+	// stored with the planned value along with the error, which leaves a tainted broker behind
+	if known(plannedState.SempBasicAuthEnabled) {
+		var authDiags diag.Diagnostics
+		r.setSempBasicAuth(ctx, resourceId, plannedState.SempBasicAuthEnabled.ValueBool(), &authDiags)
+		if authDiags.HasError() {
+			resp.Diagnostics.AddError(
+				"Error setting SEMP basic authentication",
+				fmt.Sprintf("Broker service %s was created, but setting semp_basic_auth_enabled failed: %s\n"+
+					"The broker is tainted, run terraform untaint to keep it instead of replacing it.", resourceId, authDiags.Errors()[0].Detail()),
+			)
+			resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+			return
 		}
-
 	}
 
 	// Set state to fully populated data
@@ -396,6 +614,12 @@ func (r *brokerResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// only a managed setting is checked for drift, and only if asked for
+	if known(currentState.SempBasicAuthEnabled) && currentState.SempBasicAuthProbe.ValueBool() {
+		httpClient := &http.Client{Timeout: sempBasicAuthProbeTimeout}
+		refreshSempBasicAuth(ctx, httpClient, &currentState, &resp.Diagnostics)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &currentState)
 	resp.Diagnostics.Append(diags...)
@@ -414,19 +638,115 @@ func (r *brokerResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	var currentState brokerResourceModel
+	diags = req.State.Get(ctx, &currentState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Generate API request body from plan
-	var body = missioncontrol.UpdateServiceJSONRequestBody{
-		Name: plannedState.Name.ValueStringPointer(),
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
 	brokerId := plannedState.ID.ValueString()
 
+	// increasing the message spool is done in place (decreasing forces a replacement, see schema)
+	if !plannedState.MaxSpoolUsage.IsUnknown() && plannedState.MaxSpoolUsage.ValueInt32() > currentState.MaxSpoolUsage.ValueInt32() {
+		r.updateMessageSpool(ctx, brokerId, plannedState.MaxSpoolUsage.ValueInt32(), updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// version changes are upgrades (downgrades are rejected at plan time, see schema)
+	if !plannedState.EventBrokerVersion.IsUnknown() && !plannedState.EventBrokerVersion.Equal(currentState.EventBrokerVersion) {
+		r.upgradeVersion(ctx, brokerId, plannedState.EventBrokerVersion.ValueString(), updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Generate API request body from plan, containing only the changed attributes
+	var body = missioncontrol.UpdateServiceJSONRequestBody{}
+	if !plannedState.Name.Equal(currentState.Name) {
+		body.Name = plannedState.Name.ValueStringPointer()
+	}
+	if !plannedState.Locked.IsUnknown() && !plannedState.Locked.Equal(currentState.Locked) {
+		body.Locked = plannedState.Locked.ValueBoolPointer()
+	}
+
+	if !plannedState.OwnedBy.IsUnknown() && !plannedState.OwnedBy.Equal(currentState.OwnedBy) {
+		body.OwnedBy = plannedState.OwnedBy.ValueStringPointer()
+	}
+
+	if body.Name != nil || body.Locked != nil || body.OwnedBy != nil {
+		r.updateService(ctx, brokerId, body, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// not configured anymore means no longer managed, the broker keeps its setting
+	if known(plannedState.SempBasicAuthEnabled) && !plannedState.SempBasicAuthEnabled.Equal(currentState.SempBasicAuthEnabled) {
+		r.setSempBasicAuth(ctx, brokerId, plannedState.SempBasicAuthEnabled.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Update will NOT deliver expanded infos (epand query param is not specified for this method)
+	// Therfore we get the full info again
+	// Get refreshed broker state
+	r.fullGet(ctx, plannedState.ID.ValueString(), &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updated broker to %s %v %v", plannedState.Name.ValueString(), plannedState.Status.ValueString(), plannedState.LastUpdated.ValueString()))
+
+	// Save updated data into Terraform state
+	diags = resp.State.Set(ctx, &plannedState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+// helper to convert the configured service_connection_endpoint blocks, nil means default endpoints
+func (r *brokerResource) connectionEndpointsFromPlan(ctx context.Context, endpoints types.List, diagnostics *diag.Diagnostics) *[]missioncontrol.ConnectionEndpoint {
+	if endpoints.IsNull() || endpoints.IsUnknown() || len(endpoints.Elements()) == 0 {
+		return nil
+	}
+	var endpointModels []serviceConnectionEndpointModel
+	diagnostics.Append(endpoints.ElementsAs(ctx, &endpointModels, false)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	result := []missioncontrol.ConnectionEndpoint{}
+	for _, e := range endpointModels {
+		result = append(result, missioncontrol.ConnectionEndpoint{
+			Name:        e.Name.ValueString(),
+			Description: nullIfEmptyStringPtr(e.Description),
+			AccessType:  missioncontrol.ConnectionEndpointAccessType(e.AccessType.ValueString()),
+			Ports:       endpointPortsFromMap(ctx, e.Ports, diagnostics),
+		})
+	}
+	return &result
+}
+
+// helper to update the basic attributes of a broker service
+func (r *brokerResource) updateService(ctx context.Context, brokerId string, body missioncontrol.UpdateServiceJSONRequestBody, diagnostics *diag.Diagnostics) {
 	// Use client to update broker
 	tflog.Info(ctx, fmt.Sprintf("Updating broker service using %v", body))
 
-	updateResp, err := r.cMProviderData.Client.UpdateServiceWithResponse(ctx, brokerId, body, r.BearerReqEditorFn)
+	updateResp, err := r.cMProviderData.Client.UpdateServiceWithResponse(ctx, brokerId, body, r.cMProviderData.BearerReqEditorFn)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error updating broker service",
 			"Could not update broker service, unexpected error: "+err.Error(),
 		)
@@ -435,33 +755,185 @@ func (r *brokerResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// NOTE: in theory we will get a PENDING or INPROGRESS status, and should wait for the operatin to finish.
 	// It is only a quick renaming however, so we do not bother...
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", updateResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(updateResp.Body)))
 	if updateResp.StatusCode() != 200 {
 		// do not catch 404 (vanished resources), that is an error
-		resp.Diagnostics.AddError(
-			"Error creating broker service",
-			fmt.Sprintf("Unexpected response code: %v", updateResp.StatusCode()),
+		diagnostics.AddError(
+			"Error updating broker service",
+			fmt.Sprintf("Unexpected response code: %v\n%s", updateResp.StatusCode(), parseErrorResponse(updateResp.Body)),
 		)
 		return
 	}
+}
 
-	// Update will NOT deliver expanded infos (epand query param is not specified for this method)
-	// Therfore we get the full info again
-	// Get refreshed broker state
-	r.fullGet(ctx, plannedState.ID.ValueString(), &plannedState, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+// helper to enable or disable basic authentication for management access
+func (r *brokerResource) setSempBasicAuth(ctx context.Context, brokerId string, enabled bool, diagnostics *diag.Diagnostics) {
+	tflog.Info(ctx, fmt.Sprintf("Setting SEMP basic authentication of broker service %s to %t", brokerId, enabled))
+
+	authResp, err := r.cMProviderData.Client.DisableOrEnableWithResponse(ctx, brokerId, missioncontrol.BasicAuthAvailability{Enabled: enabled}, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error setting SEMP basic authentication",
+			"Could not set SEMP basic authentication, unexpected error: "+err.Error(),
+		)
 		return
 	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(authResp.Body)))
+	if authResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error setting SEMP basic authentication",
+			fmt.Sprintf("Unexpected response code: %v\n%s", authResp.StatusCode(), parseErrorResponse(authResp.Body)),
+		)
+	}
+}
 
-	tflog.Info(ctx, fmt.Sprintf("Updated broker to %s %v %v", plannedState.Name.ValueString(), plannedState.Status.ValueString(), plannedState.LastUpdated.ValueString()))
+// helper to detect drift of the basic authentication for management access. There is no api to read it,
+// so the management endpoint is probed using the admin credentials. Inconclusive probes keep the value of the state
+func refreshSempBasicAuth(ctx context.Context, httpClient *http.Client, model *brokerResourceModel, diagnostics *diag.Diagnostics) {
+	url := managementURL(ctx, model.AllConnectionEndpoints, diagnostics)
+	if diagnostics.HasError() || url == "" {
+		return
+	}
+	enabled, ok := probeSempBasicAuth(ctx, httpClient, url, model.MgmtAdminUserName.ValueString(), model.MgmtAdminPassword.ValueString())
+	if ok {
+		model.SempBasicAuthEnabled = types.BoolValue(enabled)
+	}
+}
 
-	// Save updated data into Terraform state
-	diags = resp.State.Set(ctx, &plannedState)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// helper to increase the message spool of a broker service and wait for the operation to finish
+func (r *brokerResource) updateMessageSpool(ctx context.Context, brokerId string, spoolSize int32, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	body := missioncontrol.UpdateMessageSpoolJSONRequestBody{
+		MessageSpoolSizeInGB: spoolSize,
+	}
+	tflog.Info(ctx, fmt.Sprintf("Updating message spool of broker service %s to %d GB", brokerId, spoolSize))
+
+	spoolResp, err := r.cMProviderData.Client.UpdateMessageSpoolWithResponse(ctx, brokerId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error updating broker message spool",
+			"Could not update broker message spool, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(spoolResp.Body)))
+	if spoolResp.StatusCode() != 202 {
+		diagnostics.AddError(
+			"Error updating broker message spool",
+			fmt.Sprintf("Unexpected response code: %v\n%s", spoolResp.StatusCode(), parseErrorResponse(spoolResp.Body)),
+		)
+		return
+	}
+
+	operationId := *(spoolResp.JSON202.Data.Id)
+	op := waitForServiceOperation(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, brokerId, operationId, timeout, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	if op == nil {
+		diagnostics.AddError(
+			"Error updating broker message spool",
+			fmt.Sprintf("Could not find operation %s of broker service %s", operationId, brokerId),
+		)
+		return
+	}
+	if *op.Status == missioncontrol.OperationStatusFAILED {
+		diagnostics.AddError(
+			"Error updating broker message spool",
+			operationErrorMessage(op),
+		)
+	}
+}
+
+// helper upgrading the broker to the given version right away, waiting for the maintenance activity to finish
+func (r *brokerResource) upgradeVersion(ctx context.Context, brokerId string, version string, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	readinessResp, err := r.cMProviderData.Client.GetUpgradeReadinessWithResponse(ctx, brokerId, nil, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error upgrading broker",
+			"Could not check upgrade readiness, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(readinessResp.Body)))
+	if readinessResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error upgrading broker",
+			fmt.Sprintf("Unexpected response code: %v\n%s", readinessResp.StatusCode(), parseErrorResponse(readinessResp.Body)),
+		)
+		return
+	}
+	if readiness := readinessResp.JSON200.Data; readiness != nil && readiness.Status != nil && *readiness.Status != missioncontrol.OK {
+		diagnostics.AddError(
+			"Error upgrading broker",
+			fmt.Sprintf("Broker service %s is not ready for an upgrade: %s", brokerId, stringValue(readiness.Message)),
+		)
+		return
+	}
+
+	body := missioncontrol.CreateEventBrokerServiceUpgradeJSONRequestBody{
+		TargetVersion: version,
+	}
+	tflog.Info(ctx, fmt.Sprintf("Upgrading broker service %s to %s", brokerId, version))
+	upgradeResp, err := r.cMProviderData.Client.CreateEventBrokerServiceUpgradeWithResponse(ctx, brokerId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error upgrading broker",
+			"Could not upgrade broker, unexpected error: "+err.Error(),
+		)
 		return
 	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(upgradeResp.Body)))
+	if upgradeResp.StatusCode() != 201 {
+		diagnostics.AddError(
+			"Error upgrading broker",
+			fmt.Sprintf("Unexpected response code: %v\n%s", upgradeResp.StatusCode(), parseErrorResponse(upgradeResp.Body)),
+		)
+		return
+	}
+	activityId := stringValue(upgradeResp.JSON201.Data.Id)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if time.Now().After(deadline) {
+			diagnostics.AddError(
+				"Timeout",
+				fmt.Sprintf("timeout waiting for upgrade %s of broker service %s", activityId, brokerId),
+			)
+			return
+		}
+		if !sleepWithContext(ctx, r.cMProviderData.PollingIntervalDuration) {
+			diagnostics.AddError(
+				"Cancelled",
+				fmt.Sprintf("cancelled while waiting for upgrade %s of broker service %s", activityId, brokerId),
+			)
+			return
+		}
 
+		activity := getUpgradeActivity(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, brokerId, activityId, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+		if activity == nil {
+			diagnostics.AddError(
+				"Error upgrading broker",
+				fmt.Sprintf("Could not find upgrade %s of broker service %s", activityId, brokerId),
+			)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Upgrade %s of broker service %s has status %s", activityId, brokerId, maintenanceActivityStatus(activity)))
+		switch missioncontrol.MaintenanceActivityMaintenanceActivityStatus(maintenanceActivityStatus(activity)) {
+		case missioncontrol.MaintenanceActivityMaintenanceActivityStatusCOMPLETED:
+			return
+		case missioncontrol.MaintenanceActivityMaintenanceActivityStatusFAILED,
+			missioncontrol.MaintenanceActivityMaintenanceActivityStatusCANCELLED,
+			missioncontrol.MaintenanceActivityMaintenanceActivityStatusSKIPPED:
+			diagnostics.AddError(
+				"Error upgrading broker",
+				maintenanceActivityMessage(activity),
+			)
+			return
+		}
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -474,17 +946,36 @@ func (r *brokerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// then delete
-	brokerId := currentState.ID.ValueString()
-	delResp, err := r.cMProviderData.Client.DeleteServiceWithResponse(ctx, brokerId, r.BearerReqEditorFn)
+	if currentState.Locked.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("locked"),
+			"Broker service is locked",
+			fmt.Sprintf("The broker service %s has deletion protection enabled. "+
+				"Set locked = false and apply the change before destroying (or replacing) the broker.", currentState.ID.ValueString()),
+		)
+		return
+	}
+
+	deleteTimeout, diags := currentState.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.deleteService(ctx, currentState.ID.ValueString(), deleteTimeout, &resp.Diagnostics)
+}
+
+// helper to delete a broker service and wait for the deletion to finish
+func (r *brokerResource) deleteService(ctx context.Context, brokerId string, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	delResp, err := r.cMProviderData.Client.DeleteServiceWithResponse(ctx, brokerId, r.cMProviderData.BearerReqEditorFn)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error getting broker service info",
 			"Could not get broker service, unexpected error: "+err.Error(),
 		)
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", delResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
 	if delResp.StatusCode() != 202 {
 
 		// handling a vanished resource (likely already detected in plan/read)
@@ -496,17 +987,83 @@ func (r *brokerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 				return
 			}
 		}
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error Checking broker status",
 			fmt.Sprintf("Unexpected response code: %v", delResp.StatusCode()),
 		)
-		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", delResp.Body))
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
 		return
 	}
 	operationId := *(delResp.JSON202.Data.Id)
 	tflog.Debug(ctx, fmt.Sprintf("Delete-Operation %s on broker %s has been started.", operationId, brokerId))
 
-	// TODO we might want to wait for the operation to finish?
+	// wait for the deletion to finish, so that a broker with the same custom router name can be recreated right away
+	op := waitForServiceOperation(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, brokerId, operationId, timeout, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	if op == nil {
+		// the operation vanished together with the service
+		tflog.Info(ctx, fmt.Sprintf("Delete-Operation %s on broker %s is gone, assuming the broker has been deleted", operationId, brokerId))
+		return
+	}
+	if *op.Status == missioncontrol.OperationStatusFAILED {
+		diagnostics.AddError(
+			"Error deleting broker service",
+			operationErrorMessage(op),
+		)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Delete-Operation %s on broker %s has finished.", operationId, brokerId))
+}
+
+// helper to handle a broker service whose creation failed: reports the failure details of the creation operation and
+// either deletes the half-created service (delete_on_failure) or keeps it in the state, so terraform marks it as tainted
+func (r *brokerResource) handleFailedCreation(ctx context.Context, serviceId string, operationId string, model *brokerResourceModel, resp *resource.CreateResponse) {
+	resp.Diagnostics.AddError(
+		"Error creating broker service",
+		fmt.Sprintf("Broker service %s has creation state %s\n%s", serviceId, model.Status.ValueString(), r.getOperationFailure(ctx, serviceId, operationId)),
+	)
+
+	if model.DeleteOnFailure.ValueBool() {
+		tflog.Warn(ctx, fmt.Sprintf("Deleting broker service %s after failed creation", serviceId))
+		// separate diagnostics, deleteService bails out on existing errors
+		var deleteDiags diag.Diagnostics
+		deleteTimeout, diags := model.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+		deleteDiags.Append(diags...)
+		r.deleteService(ctx, serviceId, deleteTimeout, &deleteDiags)
+		resp.Diagnostics.Append(deleteDiags...)
+		if !deleteDiags.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	// keep the failed service in the state (its id has been stored already), it will be replaced (or can be destroyed) later on
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), model.Status)...)
+}
+
+// helper to retrieve the failure reason of a service operation
+func (r *brokerResource) getOperationFailure(ctx context.Context, serviceId string, operationId string) string {
+	expand := "progressLogs"
+	opResp, err := r.cMProviderData.Client.GetServiceOperationWithResponse(ctx, serviceId, operationId, &missioncontrol.GetServiceOperationParams{Expand: &expand}, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		return "Could not get operation details, unexpected error: " + err.Error()
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(opResp.Body)))
+	if opResp.StatusCode() != 200 {
+		return fmt.Sprintf("Could not get operation details, unexpected response code: %v", opResp.StatusCode())
+	}
+
+	errMsg := operationErrorMessage(&opResp.JSON200.Data)
+	if opResp.JSON200.Data.ProgressLogs != nil && len(*opResp.JSON200.Data.ProgressLogs) > 0 {
+		progressLogs := *opResp.JSON200.Data.ProgressLogs
+		lastLog := progressLogs[len(progressLogs)-1]
+		if lastLog.Message != nil {
+			errMsg = errMsg + fmt.Sprintf("\nLast progress log: %s", *lastLog.Message)
+		}
+	}
+	return errMsg
 }
 
 func (r *brokerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -525,7 +1082,7 @@ func (r *brokerResource) fullGet(ctx context.Context, id string, model *brokerRe
 	}
 
 	// Get refreshed broker state
-	getResp, err := r.cMProviderData.Client.GetServiceWithResponse(ctx, id, &getParams, r.BearerReqEditorFn)
+	getResp, err := r.cMProviderData.Client.GetServiceWithResponse(ctx, id, &getParams, r.cMProviderData.BearerReqEditorFn)
 	if err != nil {
 		diagnostics.AddError(
 			"Error getting broker service",
@@ -533,7 +1090,7 @@ func (r *brokerResource) fullGet(ctx context.Context, id string, model *brokerRe
 		)
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", getResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
 	if getResp.StatusCode() != 200 {
 
 		// handle vanished resources
@@ -583,7 +1140,9 @@ func (r *brokerResource) fullGet(ctx context.Context, id string, model *brokerRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", getResp.Body))
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	model.Status = types.StringValue(string(*(getResp.JSON200.Data.CreationState)))
+	model.Locked = types.BoolValue(getResp.JSON200.Data.Locked != nil && *(getResp.JSON200.Data.Locked))
 	// extract all infos when status is COMPLETED
 	if *(getResp.JSON200.Data.CreationState) == missioncontrol.ServiceCreationStateCOMPLETED {
 		model.ID = types.StringPointerValue(getResp.JSON200.Data.Id)
@@ -598,15 +1157,17 @@ func (r *brokerResource) fullGet(ctx context.Context, id string, model *brokerRe
 			model.LastUpdated = types.StringValue("")
 		}
 		model.ServiceClassId = types.StringPointerValue((*string)(getResp.JSON200.Data.ServiceClassId))
+		model.EnvironmentId = types.StringPointerValue(getResp.JSON200.Data.EnvironmentId)
+		model.OwnedBy = types.StringPointerValue(getResp.JSON200.Data.OwnedBy)
 		model.DataCenterId = types.StringPointerValue(getResp.JSON200.Data.DatacenterId)
 		model.EventBrokerVersion = types.StringValue(getResp.JSON200.Data.EventBrokerServiceVersion)
-		model.Status = types.StringValue(string(*(getResp.JSON200.Data.CreationState)))
 		model.Name = types.StringPointerValue(getResp.JSON200.Data.Name)
 		model.ClusterName = types.StringPointerValue(getResp.JSON200.Data.Broker.Cluster.Name)
 
 		model.CustomRouterName = types.StringValue(getRouterPrefix(*(getResp.JSON200.Data.Broker.Cluster.PrimaryRouterName)))
 		model.MsgVpnName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MsgVpnName)
 		model.MaxSpoolUsage = types.Int32PointerValue(getResp.JSON200.Data.Broker.MaxSpoolUsage)
+		model.RedundancyGroupSsl = types.BoolValue(getResp.JSON200.Data.Broker.RedundancyGroupSslEnabled != nil && *getResp.JSON200.Data.Broker.RedundancyGroupSslEnabled)
 		model.MissionControlUserName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MissionControlManagerLoginCredential.Username)
 		model.MissionControlPassword = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].MissionControlManagerLoginCredential.Password)
 		model.MgmtAdminUserName = types.StringPointerValue((*(getResp.JSON200.Data.Broker.MsgVpns))[0].ManagementAdminLoginCredential.Username)
@@ -621,6 +1182,12 @@ func (r *brokerResource) fullGet(ctx context.Context, id string, model *brokerRe
 			return
 		}
 
+		// the flat attributes above only cover the first endpoint
+		model.AllConnectionEndpoints = connectionEndpointsValue(ctx, getResp.JSON200.Data.ServiceConnectionEndpoints, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+
 		tflog.Debug(ctx, fmt.Sprintf("Read Broker state %s %s %s %v", model.ID, model.Name, model.Status.ValueString(), model.LastUpdated))
 	}
 
@@ -9,7 +9,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
@@ -29,6 +31,22 @@ func stopFakeServer() {
 	}
 }
 
+// verify that destroyed brokers are actually gone (delete waits for the operation to finish)
+func testAccCheckBrokerDestroyed(s *terraform.State) error {
+	if svr == nil {
+		return nil
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gsolaceclustermgr_broker" {
+			continue
+		}
+		if svr.HasService(rs.Primary.ID) {
+			return fmt.Errorf("broker service %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
 func TestAccBrokerResource(t *testing.T) {
 	if os.Getenv("FAKE_SERVER_EXT") == "" {
 		startFakeServer()
@@ -36,6 +54,7 @@ func TestAccBrokerResource(t *testing.T) {
 	}
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
 		Steps: []resource.TestStep{
 			// validation errors
 			{
@@ -46,6 +65,10 @@ func TestAccBrokerResource(t *testing.T) {
 				Config:      testResourceConfigAll("test", "ocs-prov-test", "ocsrouter", 1),
 				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
 			},
+			{
+				Config:      testResourceConfigTimeouts("test", "ocs-prov-test", "1hour"),
+				ExpectError: regexp.MustCompile("Invalid Duration"),
+			},
 			{
 				Config: testResourceConfigAll("test", "ocs-prov-test", "ocsrouter", 23),
 				ConfigStateChecks: []statecheck.StateCheck{
@@ -144,11 +167,44 @@ func TestAccBrokerResource(t *testing.T) {
 					),
 				},
 			},
+			// increasing the spool is done in place
+			{
+				Config: testResourceConfigAll("test", "ocs-prov-test", "ocsrouter", 40),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test",
+						tfjsonpath.New("max_spool_usage"),
+						knownvalue.Int32Exact(40),
+					),
+				},
+			},
+			// decreasing the spool forces a replacement
+			{
+				Config: testResourceConfigAll("test", "ocs-prov-test", "ocsrouter", 30),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test",
+						tfjsonpath.New("max_spool_usage"),
+						knownvalue.Int32Exact(30),
+					),
+				},
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
 		Steps: []resource.TestStep{
 			// Create and Read testing (optionals not set)
 			{
@@ -269,10 +325,335 @@ func TestAccBrokerResource(t *testing.T) {
 					),
 				},
 			},
+			// adding a timeouts block only changes the state
+			{
+				Config: testResourceConfigTimeouts("test2", "ocs-prov-test-changed", "2m"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test2", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test2",
+						tfjsonpath.New("timeouts").AtMapKey("create"),
+						knownvalue.StringExact("2m"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test2",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("ocs-prov-test-changed"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+
+}
+
+func TestAccBrokerResourceFailedCreation(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	// the fakeserver lets the creation of services named "fail..." fail
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// failed service is deleted right away
+			{
+				Config:      testResourceConfigDeleteOnFailure("test4", "fail-prov-test4", true),
+				ExpectError: regexp.MustCompile("Creation of event broker service failed"),
+			},
+		},
+	})
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// failed service is kept as tainted resource and deleted on destroy
+			{
+				Config:      testResourceConfigDeleteOnFailure("test5", "fail-prov-test5", false),
+				ExpectError: regexp.MustCompile("Creation of event broker service failed"),
+			},
+		},
+	})
+}
+
+func TestAccBrokerResourceLocked(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceConfigLocked("test6", "ocs-prov-test6", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test6",
+						tfjsonpath.New("locked"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// locked brokers cannot be destroyed
+			{
+				Config:      testResourceConfigLocked("test6", "ocs-prov-test6", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Broker service is locked"),
+			},
+			// unlock in place
+			{
+				Config: testResourceConfigLocked("test6", "ocs-prov-test6", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test6", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test6",
+						tfjsonpath.New("locked"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
+}
 
+func TestAccBrokerResourceOwnership(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceConfigOwnership("test7", "ocs-prov-test7", "test-env2", "test-user2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test7",
+						tfjsonpath.New("environment_id"),
+						knownvalue.StringExact("test-env2"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test7",
+						tfjsonpath.New("owned_by"),
+						knownvalue.StringExact("test-user2"),
+					),
+				},
+			},
+			// ownership transfer in place
+			{
+				Config: testResourceConfigOwnership("test7", "ocs-prov-test7", "test-env2", "test-user3"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test7", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test7",
+						tfjsonpath.New("owned_by"),
+						knownvalue.StringExact("test-user3"),
+					),
+				},
+			},
+			// changing the environment forces a replacement
+			{
+				Config: testResourceConfigOwnership("test7", "ocs-prov-test7", "test-env3", "test-user3"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test7", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBrokerResourceRedundancyGroupSsl(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// only allowed for HA service classes
+			{
+				Config:      testResourceConfigRedundancyGroupSsl("test8", "ocs-prov-test8", "ENTERPRISE_250_STANDALONE", "true"),
+				ExpectError: regexp.MustCompile("only supported for \\*_HIGHAVAILABILITY service classes"),
+			},
+			// explicitly disabling it is fine for any service class
+			{
+				Config:             testResourceConfigRedundancyGroupSsl("test8", "ocs-prov-test8", "ENTERPRISE_250_STANDALONE", "false"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testResourceConfigRedundancyGroupSsl("test8", "ocs-prov-test8", "ENTERPRISE_250_HIGHAVAILABILITY", "true"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test8",
+						tfjsonpath.New("redundancy_group_ssl_enabled"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBrokerResourceUpgrade(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceConfigVersion("test11", "ocs-prov-test11", "10.8.1.152"),
+			},
+			// upgraded in place
+			{
+				Config: testResourceConfigVersion("test11", "ocs-prov-test11", "10.9.0.40"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test11", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test11",
+						tfjsonpath.New("event_broker_version"),
+						knownvalue.StringExact("10.9.0.40"),
+					),
+				},
+			},
+			// downgrades are rejected at plan time
+			{
+				Config:      testResourceConfigVersion("test11", "ocs-prov-test11", "10.8.1.152"),
+				ExpectError: regexp.MustCompile("can't be downgraded"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBrokerResourceSempBasicAuth(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// set after creation
+			{
+				Config: testResourceConfigSempBasicAuth("test10", "ocs-prov-test10", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test10",
+						tfjsonpath.New("semp_basic_auth_enabled"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// the unreachable management endpoint keeps the value
+			{
+				Config: testResourceConfigSempBasicAuth("test10", "ocs-prov-test10", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// changed in place
+			{
+				Config: testResourceConfigSempBasicAuth("test10", "ocs-prov-test10", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test10", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test10",
+						tfjsonpath.New("semp_basic_auth_enabled"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBrokerResourceConnectionEndpoints(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// unknown protocols are rejected
+			{
+				Config:      testResourceConfigConnectionEndpoint("test9", "ocs-prov-test9", "PRIVATE", "serviceFooListenPort"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			{
+				Config: testResourceConfigConnectionEndpoint("test9", "ocs-prov-test9", "PRIVATE", "serviceSmfTlsListenPort"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test9",
+						tfjsonpath.New("hostnames"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("private.test-host1")}),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test9",
+						tfjsonpath.New("service_connection_endpoints").AtSliceIndex(0).AtMapKey("ports").AtMapKey("serviceSmfTlsListenPort"),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"port":    knownvalue.Int32Exact(55443),
+							"enabled": knownvalue.Bool(true),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.test9",
+						tfjsonpath.New("service_connection_endpoints").AtSliceIndex(0).AtMapKey("access_type"),
+						knownvalue.StringExact("PRIVATE"),
+					),
+				},
+			},
+			// endpoints are only set on creation
+			{
+				Config: testResourceConfigConnectionEndpoint("test9", "ocs-prov-test9", "PUBLIC", "serviceSmfTlsListenPort"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test9", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
 }
 
 func TestAccBrokerDataSource(t *testing.T) {
@@ -403,6 +784,163 @@ func testResourceConfig(rname string, name string) string {
 	`
 }
 
+func testResourceConfigTimeouts(rname string, name string, createTimeout string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + name + `"
+		datacenter_id   = "aks-germanywestcentral"
+		timeouts {
+			create = "` + createTimeout + `"
+			delete = "2m"
+		}
+	}
+	`
+}
+
+func testResourceConfigDeleteOnFailure(rname string, name string, deleteOnFailure bool) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id   = "ENTERPRISE_250_STANDALONE"
+		name              = "` + name + `"
+		datacenter_id     = "aks-germanywestcentral"
+		delete_on_failure = ` + fmt.Sprint(deleteOnFailure) + `
+	}
+	`
+}
+
+func testResourceConfigLocked(rname string, name string, locked bool) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + name + `"
+		datacenter_id   = "aks-germanywestcentral"
+		locked          = ` + fmt.Sprint(locked) + `
+	}
+	`
+}
+
+func testResourceConfigOwnership(rname string, name string, environmentId string, ownedBy string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + name + `"
+		datacenter_id   = "aks-germanywestcentral"
+		environment_id  = "` + environmentId + `"
+		owned_by        = "` + ownedBy + `"
+	}
+	`
+}
+
+func testResourceConfigRedundancyGroupSsl(rname string, name string, serviceClassId string, enabled string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id              = "` + serviceClassId + `"
+		name                         = "` + name + `"
+		datacenter_id                = "aks-germanywestcentral"
+		redundancy_group_ssl_enabled = ` + enabled + `
+	}
+	`
+}
+
+func testResourceConfigVersion(rname string, name string, version string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id      = "ENTERPRISE_250_STANDALONE"
+		name                 = "` + name + `"
+		datacenter_id        = "aks-germanywestcentral"
+		event_broker_version = "` + version + `"
+	}
+	`
+}
+
+func TestAccBrokerResourceSempBasicAuthFailure(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// the fakeserver rejects the setting for services named "authfail...", the created broker is kept in state
+			{
+				Config:      testResourceConfigSempBasicAuth("test11", "authfail-prov-test11", false),
+				ExpectError: regexp.MustCompile("setting semp_basic_auth_enabled failed"),
+			},
+			// the tainted broker is replaced, as the setting fails again so is the replacement
+			{
+				Config: testResourceConfigSempBasicAuth("test11", "authfail-prov-test11", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test11", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ExpectError: regexp.MustCompile("setting semp_basic_auth_enabled failed"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBrokerResourceCreateTimeout(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// the fakeserver takes longer than the create timeout
+			{
+				Config:      testResourceConfigTimeouts("test12", "ocs-prov-test12", "2s"),
+				ExpectError: regexp.MustCompile("timeout waiting for operation"),
+			},
+			// the broker has been kept in state as tainted resource instead of leaking it
+			{
+				Config: testResourceConfigTimeouts("test12", "ocs-prov-test12", "2m"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker.test12", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testResourceConfigSempBasicAuth(rname string, name string, enabled bool) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id         = "ENTERPRISE_250_STANDALONE"
+		name                    = "` + name + `"
+		datacenter_id           = "aks-germanywestcentral"
+		semp_basic_auth_enabled = ` + fmt.Sprint(enabled) + `
+		semp_basic_auth_probe   = true
+	}
+	`
+}
+
+func testResourceConfigConnectionEndpoint(rname string, name string, accessType string, protocol string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + name + `"
+		datacenter_id   = "aks-germanywestcentral"
+		service_connection_endpoint {
+			name        = "private"
+			access_type = "` + accessType + `"
+			ports = {
+				` + protocol + `               = 55443
+				serviceManagementTlsListenPort = 943
+			}
+		}
+	}
+	`
+}
+
 func testDataSourceConfig(rname string, id string) string {
 	return providerConfig + `
 	data "gsolaceclustermgr_broker" "` + rname + `" {
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type brokerStateDataSourceModel struct {
+	ServiceId          types.String   `tfsdk:"service_id"`
+	WaitForHealthy     types.Bool     `tfsdk:"wait_for_healthy"`
+	ID                 types.String   `tfsdk:"id"`
+	IsHighAvailability types.Bool     `tfsdk:"is_high_availability"`
+	ActiveNode         types.String   `tfsdk:"active_node"`
+	Redundancy         types.String   `tfsdk:"redundancy"`
+	ConfigSync         types.String   `tfsdk:"config_sync"`
+	CreationState      types.String   `tfsdk:"creation_state"`
+	Healthy            types.Bool     `tfsdk:"healthy"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &brokerStateDataSource{}
+	_ datasource.DataSourceWithConfigure = &brokerStateDataSource{}
+)
+
+// NewBrokerStateDataSource is a helper function to simplify the provider implementation.
+func NewBrokerStateDataSource() datasource.DataSource {
+	return &brokerStateDataSource{}
+}
+
+// brokerStateDataSource is the data source implementation.
+type brokerStateDataSource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the data source type name.
+func (d *brokerStateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_broker_state"
+}
+
+// Schema defines the schema for the data source.
+func (d *brokerStateDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The state of a broker service: its creation state, its active node and the redundancy and config-sync state of HA brokers. " +
+			"Optionally waits until the broker is healthy, so other resources can depend on it",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+			},
+			"wait_for_healthy": schema.BoolAttribute{
+				MarkdownDescription: "Wait until the broker is healthy, an error is reported if it is not healthy within the *read* timeout, which defaults to the provider *polling_timeout_duration*",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Computed:            true,
+			},
+			"is_high_availability": schema.BoolAttribute{
+				MarkdownDescription: "Whether the broker is deployed as a high availability group",
+				Computed:            true,
+			},
+			"active_node": schema.StringAttribute{
+				MarkdownDescription: "The active node of a high availability broker, PRIMARY or BACKUP. Empty for standalone brokers",
+				Computed:            true,
+			},
+			"redundancy": schema.StringAttribute{
+				MarkdownDescription: "The redundancy state of a high availability broker, UP or DOWN. Empty for standalone brokers",
+				Computed:            true,
+			},
+			"config_sync": schema.StringAttribute{
+				MarkdownDescription: "The config-sync state of a high availability broker, UP or DOWN. Empty for standalone brokers",
+				Computed:            true,
+			},
+			"creation_state": schema.StringAttribute{
+				MarkdownDescription: "The creation state of the broker service, e.g. PENDING, INPROGRESS, COMPLETED or FAILED",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the broker is healthy, i.e. its creation is COMPLETED and, for HA brokers, it has an active node with redundancy and config-sync up. " +
+					"The API reports no runtime state of standalone brokers, so a completed standalone broker is considered healthy",
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Read resource information.
+func (d *brokerStateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var currentState brokerStateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	serviceId := currentState.ServiceId.ValueString()
+
+	waitTimeout, diags := currentState.Timeouts.Read(ctx, d.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		state := getBrokerState(ctx, d.cMProviderData, d.cMProviderData.BearerReqEditorFn, serviceId, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state == nil {
+			resp.Diagnostics.AddError(
+				"Error getting broker state",
+				fmt.Sprintf("Could not find broker service %s", serviceId),
+			)
+			return
+		}
+		creationState := getServiceCreationState(ctx, d.cMProviderData, d.cMProviderData.BearerReqEditorFn, serviceId, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		healthy := brokerStateHealthy(state, creationState)
+		tflog.Info(ctx, fmt.Sprintf("Broker %s healthy: %t", serviceId, healthy))
+		if healthy || !currentState.WaitForHealthy.ValueBool() {
+			brokerStateToModel(serviceId, state, creationState, &currentState)
+			break
+		}
+		if time.Now().After(deadline) {
+			resp.Diagnostics.AddError(
+				"Timeout",
+				fmt.Sprintf("timeout waiting for broker service %s to become healthy", serviceId),
+			)
+			return
+		}
+		if !sleepWithContext(ctx, d.cMProviderData.PollingIntervalDuration) {
+			resp.Diagnostics.AddError(
+				"Cancelled",
+				fmt.Sprintf("cancelled while waiting for broker service %s to become healthy", serviceId),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *brokerStateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "configure broker state datasource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cMProviderData = cMProviderData
+}
+
+// helper telling whether a broker is healthy: its creation must be completed, HA brokers also need an active node
+// and both redundancy and config-sync up. There is no runtime state of standalone brokers, the creation state is all we know
+func brokerStateHealthy(state *missioncontrol.BrokerState, creationState string) bool {
+	if creationState != string(missioncontrol.ServiceCreationStateCOMPLETED) {
+		return false
+	}
+	if state.IsHighAvailability == nil || !*state.IsHighAvailability {
+		return true
+	}
+	redundancy := state.Redundancy
+	return redundancy != nil && redundancy.ActiveNode != nil &&
+		redundancy.Redundancy != nil && *redundancy.Redundancy == missioncontrol.RedundancyRedundancyUP &&
+		redundancy.ConfigSync != nil && *redundancy.ConfigSync == missioncontrol.RedundancyConfigSyncUP
+}
+
+// helper converting the api broker state to the model
+func brokerStateToModel(serviceId string, state *missioncontrol.BrokerState, creationState string, model *brokerStateDataSourceModel) {
+	model.ID = types.StringValue(serviceId)
+	model.IsHighAvailability = types.BoolValue(state.IsHighAvailability != nil && *state.IsHighAvailability)
+	model.ActiveNode = types.StringValue(activeNode(state))
+	model.Redundancy = types.StringValue("")
+	model.ConfigSync = types.StringValue("")
+	if state.Redundancy != nil {
+		if state.Redundancy.Redundancy != nil {
+			model.Redundancy = types.StringValue(string(*state.Redundancy.Redundancy))
+		}
+		if state.Redundancy.ConfigSync != nil {
+			model.ConfigSync = types.StringValue(string(*state.Redundancy.ConfigSync))
+		}
+	}
+	model.CreationState = types.StringValue(creationState)
+	model.Healthy = types.BoolValue(brokerStateHealthy(state, creationState))
+}
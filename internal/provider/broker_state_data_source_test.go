@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"os"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBrokerStateDataSource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// a switched over HA broker is healthy on its backup node
+			{
+				Config: testBrokerSwitchoverConfig("bs1", "ocs-prov-bs1", "ENTERPRISE_250_HIGHAVAILABILITY", "drill-1") + `
+				data "gsolaceclustermgr_broker_state" "bs1" {
+					service_id       = gsolaceclustermgr_broker_switchover.bs1.service_id
+					wait_for_healthy = true
+					timeouts {
+						read = "2m"
+					}
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_broker_state.bs1",
+						tfjsonpath.New("is_high_availability"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_broker_state.bs1",
+						tfjsonpath.New("active_node"),
+						knownvalue.StringExact("BACKUP"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_broker_state.bs1",
+						tfjsonpath.New("redundancy"),
+						knownvalue.StringExact("UP"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_broker_state.bs1",
+						tfjsonpath.New("creation_state"),
+						knownvalue.StringExact("COMPLETED"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_broker_state.bs1",
+						tfjsonpath.New("healthy"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestBrokerStateHealthy(t *testing.T) {
+	yes, no := true, false
+	primary := missioncontrol.PRIMARY
+	up, down := missioncontrol.RedundancyRedundancyUP, missioncontrol.RedundancyRedundancyDOWN
+	syncUp := missioncontrol.RedundancyConfigSyncUP
+	completed := string(missioncontrol.ServiceCreationStateCOMPLETED)
+	tests := []struct {
+		name          string
+		state         missioncontrol.BrokerState
+		creationState string
+		want          bool
+	}{
+		{"standalone", missioncontrol.BrokerState{IsHighAvailability: &no}, completed, true},
+		{"standalone pending", missioncontrol.BrokerState{IsHighAvailability: &no}, string(missioncontrol.ServiceCreationStatePENDING), false},
+		{"standalone failed", missioncontrol.BrokerState{IsHighAvailability: &no}, string(missioncontrol.ServiceCreationStateFAILED), false},
+		{"standalone vanished", missioncontrol.BrokerState{IsHighAvailability: &no}, "", false},
+		{"unknown", missioncontrol.BrokerState{}, completed, true},
+		{"ha without redundancy", missioncontrol.BrokerState{IsHighAvailability: &yes}, completed, false},
+		{"ha up", missioncontrol.BrokerState{IsHighAvailability: &yes, Redundancy: &missioncontrol.Redundancy{ActiveNode: &primary, Redundancy: &up, ConfigSync: &syncUp}}, completed, true},
+		{"ha up pending", missioncontrol.BrokerState{IsHighAvailability: &yes, Redundancy: &missioncontrol.Redundancy{ActiveNode: &primary, Redundancy: &up, ConfigSync: &syncUp}}, string(missioncontrol.ServiceCreationStateINPROGRESS), false},
+		{"ha down", missioncontrol.BrokerState{IsHighAvailability: &yes, Redundancy: &missioncontrol.Redundancy{ActiveNode: &primary, Redundancy: &down, ConfigSync: &syncUp}}, completed, false},
+		{"ha without active node", missioncontrol.BrokerState{IsHighAvailability: &yes, Redundancy: &missioncontrol.Redundancy{Redundancy: &up, ConfigSync: &syncUp}}, completed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := brokerStateHealthy(&tt.state, tt.creationState); got != tt.want {
+				t.Errorf("brokerStateHealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
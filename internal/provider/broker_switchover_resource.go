@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// brokerSwitchoverResourceModel maps the resource schema data.
+type brokerSwitchoverResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	ServiceId  types.String   `tfsdk:"service_id"`
+	Trigger    types.String   `tfsdk:"trigger"`
+	ActiveNode types.String   `tfsdk:"active_node"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &brokerSwitchoverResource{}
+	_ resource.ResourceWithConfigure = &brokerSwitchoverResource{}
+)
+
+// NewBrokerSwitchoverResource is a helper function to simplify the provider implementation.
+func NewBrokerSwitchoverResource() resource.Resource {
+	return &brokerSwitchoverResource{}
+}
+
+// brokerSwitchoverResource is the resource implementation.
+type brokerSwitchoverResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *brokerSwitchoverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_broker_switchover"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *brokerSwitchoverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure broker switchover resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *brokerSwitchoverResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Switchover of a high availability broker service to its standby node, e.g. for DR drills. " +
+			"A switchover is done when the resource is created and whenever *trigger* changes. Destroying the resource does not switch back",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the high availability broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"trigger": schema.StringAttribute{
+				MarkdownDescription: "Any value, changing it forces another switchover, e.g. a timestamp or a drill number",
+				Required:            true,
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active_node": schema.StringAttribute{
+				MarkdownDescription: "The currently active node, PRIMARY or BACKUP",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true}),
+		},
+	}
+}
+
+// Create switches the broker over.
+func (r *brokerSwitchoverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState brokerSwitchoverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	r.switchover(ctx, serviceId, createTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedState.ID = types.StringValue(serviceId)
+	found := r.get(ctx, serviceId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error switching over broker",
+			fmt.Sprintf("Broker service %s vanished", serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *brokerSwitchoverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState brokerSwitchoverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, currentState.ServiceId.ValueString(), &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update switches the broker over again when the trigger has changed.
+func (r *brokerSwitchoverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState brokerSwitchoverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	if !plannedState.Trigger.Equal(currentState.Trigger) {
+		r.switchover(ctx, serviceId, updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found := r.get(ctx, serviceId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error switching over broker",
+			fmt.Sprintf("Broker service %s vanished", serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete only removes the resource from the Terraform state, a switchover can't be undone.
+func (r *brokerSwitchoverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState brokerSwitchoverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Broker service %s stays on its active node %s", currentState.ServiceId.ValueString(), currentState.ActiveNode.ValueString()))
+}
+
+// helper starting a switchover and waiting for its operation
+func (r *brokerSwitchoverResource) switchover(ctx context.Context, serviceId string, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	tflog.Info(ctx, fmt.Sprintf("Switching over broker %s", serviceId))
+	switchResp, err := r.cMProviderData.Client.SwitchoverBrokerWithResponse(ctx, serviceId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error switching over broker",
+			"Could not switch over broker, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(switchResp.Body)))
+	if switchResp.StatusCode() != 202 {
+		diagnostics.AddError(
+			"Error switching over broker",
+			fmt.Sprintf("Unexpected response code: %v\n%s", switchResp.StatusCode(), parseErrorResponse(switchResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(switchResp.JSON202.Data.Id), timeout, "Error switching over broker", diagnostics)
+}
+
+// helper reading the active node into the model, returns false if the service does not exist
+func (r *brokerSwitchoverResource) get(ctx context.Context, serviceId string, model *brokerSwitchoverResourceModel, diagnostics *diag.Diagnostics) bool {
+	state := getBrokerState(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, diagnostics)
+	if diagnostics.HasError() || state == nil {
+		return false
+	}
+	model.ID = types.StringValue(serviceId)
+	model.ActiveNode = types.StringValue(activeNode(state))
+	tflog.Info(ctx, fmt.Sprintf("Active node of broker %s is %s", serviceId, model.ActiveNode.ValueString()))
+	return true
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBrokerSwitchoverResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// a standalone broker can't be switched over
+			{
+				Config:      testBrokerSwitchoverConfig("s1", "ocs-prov-s1", "ENTERPRISE_250_STANDALONE", "drill-1"),
+				ExpectError: regexp.MustCompile("not highly available"),
+			},
+			// Create switches over to the backup
+			{
+				Config: testBrokerSwitchoverConfig("s1", "ocs-prov-s1", "ENTERPRISE_250_HIGHAVAILABILITY", "drill-1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker_switchover.s1",
+						tfjsonpath.New("active_node"),
+						knownvalue.StringExact("BACKUP"),
+					),
+				},
+			},
+			// the active node does not cause diffs
+			{
+				Config: testBrokerSwitchoverConfig("s1", "ocs-prov-s1", "ENTERPRISE_250_HIGHAVAILABILITY", "drill-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// a new trigger switches back in place
+			{
+				Config: testBrokerSwitchoverConfig("s1", "ocs-prov-s1", "ENTERPRISE_250_HIGHAVAILABILITY", "drill-2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker_switchover.s1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker_switchover.s1",
+						tfjsonpath.New("active_node"),
+						knownvalue.StringExact("PRIMARY"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testBrokerSwitchoverConfig(rname string, brokerName string, serviceClassId string, trigger string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "%[3]s"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_broker_switchover" "%[1]s" {
+		service_id = gsolaceclustermgr_broker.%[1]s.id
+		trigger    = "%[4]s"
+	}
+	`, rname, brokerName, serviceClassId, trigger)
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// brokerUpgradeResourceModel maps the resource schema data.
+type brokerUpgradeResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	ServiceId              types.String `tfsdk:"service_id"`
+	TargetVersion          types.String `tfsdk:"target_version"`
+	MaintenanceWindowId    types.String `tfsdk:"maintenance_window_id"`
+	MaintenanceTimeOrdinal types.Int32  `tfsdk:"maintenance_time_ordinal"`
+	Status                 types.String `tfsdk:"status"`
+	ScheduledStartTime     types.String `tfsdk:"scheduled_start_time"`
+	ScheduledEndTime       types.String `tfsdk:"scheduled_end_time"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &brokerUpgradeResource{}
+	_ resource.ResourceWithConfigure = &brokerUpgradeResource{}
+)
+
+// NewBrokerUpgradeResource is a helper function to simplify the provider implementation.
+func NewBrokerUpgradeResource() resource.Resource {
+	return &brokerUpgradeResource{}
+}
+
+// brokerUpgradeResource is the resource implementation.
+type brokerUpgradeResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *brokerUpgradeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_broker_upgrade"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *brokerUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure broker upgrade resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *brokerUpgradeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Upgrade of a broker service scheduled in a maintenance window, it does not run during apply. " +
+			"Destroying the resource cancels the upgrade if it has not started yet. Leave *event_broker_version* of the broker unconfigured, it follows the upgrade",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_version": schema.StringAttribute{
+				MarkdownDescription: "The event broker version to upgrade to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"maintenance_window_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the maintenance window the upgrade is scheduled in",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"maintenance_time_ordinal": schema.Int32Attribute{
+				MarkdownDescription: "The ordinal of the maintenance time of the window, defaults to the next one",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.RequiresReplace(),
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the maintenance activity",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the maintenance activity, e.g. SCHEDULED, IN_PROGRESS, COMPLETED, FAILED or CANCELLED",
+				Computed:            true,
+			},
+			"scheduled_start_time": schema.StringAttribute{
+				MarkdownDescription: "The planned start of the upgrade",
+				Computed:            true,
+			},
+			"scheduled_end_time": schema.StringAttribute{
+				MarkdownDescription: "The planned end of the upgrade",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Create schedules the upgrade.
+func (r *brokerUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState brokerUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	body := missioncontrol.CreateEventBrokerServiceUpgradeJSONRequestBody{
+		TargetVersion:          plannedState.TargetVersion.ValueString(),
+		MaintenanceWindowId:    plannedState.MaintenanceWindowId.ValueStringPointer(),
+		MaintenanceTimeOrdinal: plannedState.MaintenanceTimeOrdinal.ValueInt32Pointer(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("Scheduling upgrade of broker %s using %v", serviceId, body))
+
+	upgradeResp, err := r.cMProviderData.Client.CreateEventBrokerServiceUpgradeWithResponse(ctx, serviceId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error scheduling broker upgrade",
+			"Could not schedule broker upgrade, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(upgradeResp.Body)))
+	if upgradeResp.StatusCode() != 201 {
+		resp.Diagnostics.AddError(
+			"Error scheduling broker upgrade",
+			fmt.Sprintf("Unexpected response code: %v\n%s", upgradeResp.StatusCode(), parseErrorResponse(upgradeResp.Body)),
+		)
+		return
+	}
+	activityId := stringValue(upgradeResp.JSON201.Data.Id)
+	tflog.Info(ctx, fmt.Sprintf("Upgrade %s of broker %s has been scheduled", activityId, serviceId))
+
+	// store the id right away, so a failed read leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), activityId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, serviceId, activityId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error scheduling broker upgrade",
+			fmt.Sprintf("Upgrade %s of broker service %s vanished", activityId, serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *brokerUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState brokerUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, currentState.ServiceId.ValueString(), currentState.ID.ValueString(), &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update is never called, all configurable attributes force a replacement.
+func (r *brokerUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Error updating broker upgrade",
+		"A scheduled broker upgrade can't be updated, please report this issue to the provider developers.",
+	)
+}
+
+// Delete cancels the upgrade if it has not started yet and removes the Terraform state.
+func (r *brokerUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState brokerUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := currentState.ServiceId.ValueString()
+	activityId := currentState.ID.ValueString()
+	found := r.get(ctx, serviceId, activityId, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find upgrade %s of broker service %s", activityId, serviceId))
+		// this is tolerable!
+		return
+	}
+	status := currentState.Status.ValueString()
+	if status != string(missioncontrol.MaintenanceActivityMaintenanceActivityStatusSCHEDULED) {
+		if status == string(missioncontrol.MaintenanceActivityMaintenanceActivityStatusINPROGRESS) {
+			resp.Diagnostics.AddWarning(
+				"Broker upgrade not cancelled",
+				fmt.Sprintf("Upgrade %s of broker service %s is in progress and can't be cancelled anymore", activityId, serviceId),
+			)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Upgrade %s of broker service %s has status %s, nothing to cancel", activityId, serviceId, status))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Cancelling upgrade %s of broker service %s", activityId, serviceId))
+	cancelResp, err := r.cMProviderData.Client.CancelMaintenanceActivityWithResponse(ctx, activityId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cancelling broker upgrade",
+			"Could not cancel broker upgrade, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(cancelResp.Body)))
+	if cancelResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find upgrade %s of broker service %s", activityId, serviceId))
+		// this is tolerable!
+		return
+	}
+	// the api does not document its success code
+	if cancelResp.StatusCode() < 200 || cancelResp.StatusCode() > 299 {
+		resp.Diagnostics.AddError(
+			"Error cancelling broker upgrade",
+			fmt.Sprintf("Unexpected response code: %v\n%s", cancelResp.StatusCode(), parseErrorResponse(cancelResp.Body)),
+		)
+	}
+}
+
+// helper reading the upgrade activity into the model, returns false if it does not exist
+func (r *brokerUpgradeResource) get(ctx context.Context, serviceId string, activityId string, model *brokerUpgradeResourceModel, diagnostics *diag.Diagnostics) bool {
+	activity := getUpgradeActivity(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, activityId, diagnostics)
+	if diagnostics.HasError() || activity == nil {
+		return false
+	}
+	model.ID = types.StringValue(activityId)
+	model.ServiceId = types.StringValue(serviceId)
+	model.Status = types.StringValue(maintenanceActivityStatus(activity))
+	model.ScheduledStartTime = types.StringValue(timeValue(activity.ScheduledStartTime))
+	model.ScheduledEndTime = types.StringValue(timeValue(activity.ScheduledEndTime))
+	return true
+}
+
+// helper formatting optional timestamps as RFC3339, "" for nil
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
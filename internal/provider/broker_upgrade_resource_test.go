@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBrokerUpgradeResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// a downgrade is rejected by the api
+			{
+				Config:      testBrokerUpgradeConfig("u1", "ocs-prov-u1", "0.9.0"),
+				ExpectError: regexp.MustCompile("Error scheduling broker upgrade"),
+			},
+			// Create schedules the upgrade into the maintenance window
+			{
+				Config: testBrokerUpgradeConfig("u1", "ocs-prov-u1", "10.9.0.40"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker_upgrade.u1",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("SCHEDULED"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker.u1",
+						tfjsonpath.New("event_broker_version"),
+						knownvalue.StringExact("1.0.0"),
+					),
+				},
+			},
+			// another target version cancels the upgrade and schedules a new one
+			{
+				Config: testBrokerUpgradeConfig("u1", "ocs-prov-u1", "10.10.0.17"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_broker_upgrade.u1", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_broker_upgrade.u1",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("SCHEDULED"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testBrokerUpgradeConfig(rname string, brokerName string, targetVersion string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_broker_upgrade" "%[1]s" {
+		service_id            = gsolaceclustermgr_broker.%[1]s.id
+		target_version        = "%[3]s"
+		maintenance_window_id = "mw-1"
+	}
+	`, rname, brokerName, targetVersion)
+}
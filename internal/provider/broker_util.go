@@ -1,11 +1,27 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
 
 	"github.com/clbanning/mxj/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 /** helper for handling defaults, returns nil instead of ponter to "" for empty strings */
@@ -24,6 +40,14 @@ func nullIfEmptyInt32Ptr(v basetypes.Int32Value) *int32 {
 	return v.ValueInt32Pointer()
 }
 
+/** helper for handling defaults, returns nil for unknown bool */
+func nullIfEmptyBoolPtr(v basetypes.BoolValue) *bool {
+	if v.IsUnknown() {
+		return nil
+	}
+	return v.ValueBoolPointer()
+}
+
 // extract error infos from ErrorDTO
 func parseErrorDTO(body []byte) string {
 	m, err := mxj.NewMapXml(body)
@@ -42,3 +66,474 @@ func getRouterPrefix(routerName string) string {
 	re := regexp.MustCompile(`^(.*)(primary|backup|monitoring)+(cn)?`)
 	return re.ReplaceAllString(routerName, "$1")
 }
+
+// helper to wait for the given duration, returns false if the context has been cancelled meanwhile
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// extract the error infos of a failed operation
+func operationErrorMessage(op *missioncontrol.Operation) string {
+	var opId string
+	if op.Id != nil {
+		opId = *op.Id
+	}
+	if op.Error == nil || op.Error.Message == nil {
+		return fmt.Sprintf("Operation %s failed without further details", opId)
+	}
+	errMsg := fmt.Sprintf("Operation %s failed: %s", opId, *op.Error.Message)
+	if op.Error.ErrorId != nil {
+		errMsg = errMsg + fmt.Sprintf("\nErrorId: %s", *op.Error.ErrorId)
+	}
+	return errMsg
+}
+
+// helper to poll a service operation until it has finished (SUCCEEDED or FAILED).
+// Returns nil (without adding an error) when the operation cannot be found (anymore), e.g. because its service has vanished.
+func waitForServiceOperation(ctx context.Context, pd CMProviderData, reqEditor missioncontrol.RequestEditorFn, serviceId string, operationId string, timeout time.Duration, diagnostics *diag.Diagnostics) *missioncontrol.Operation {
+	deadline := time.Now().Add(timeout)
+	for {
+		if time.Now().After(deadline) {
+			diagnostics.AddError(
+				"Timeout",
+				fmt.Sprintf("timeout waiting for operation %s on broker service %s", operationId, serviceId),
+			)
+			return nil
+		}
+		if !sleepWithContext(ctx, pd.PollingIntervalDuration) {
+			diagnostics.AddError(
+				"Cancelled",
+				fmt.Sprintf("cancelled while waiting for operation %s on broker service %s", operationId, serviceId),
+			)
+			return nil
+		}
+		tflog.Info(ctx, fmt.Sprintf("Checking operation %s on broker service %s", operationId, serviceId))
+
+		opResp, err := pd.Client.GetServiceOperationWithResponse(ctx, serviceId, operationId, &missioncontrol.GetServiceOperationParams{}, reqEditor)
+		if err != nil {
+			diagnostics.AddError(
+				"Error getting broker service operation",
+				"Could not get broker service operation, unexpected error: "+err.Error(),
+			)
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(opResp.Body)))
+		if opResp.StatusCode() == 404 {
+			tflog.Warn(ctx, fmt.Sprintf("Could not find operation %s on broker service %s", operationId, serviceId))
+			return nil
+		}
+		if opResp.StatusCode() != 200 {
+			var errMsg string
+			switch {
+			case opResp.JSON401 != nil:
+				errMsg = *(opResp.JSON401.Message)
+			case opResp.JSON403 != nil:
+				errMsg = *(opResp.JSON403.Message)
+			case opResp.StatusCode() == 401 || opResp.StatusCode() == 403:
+				errMsg = parseErrorDTO(opResp.Body)
+			default:
+				errMsg = fmt.Sprintf("Unexpected response code: %v", opResp.StatusCode())
+			}
+			diagnostics.AddError(
+				"Error getting broker service operation",
+				errMsg,
+			)
+			return nil
+		}
+
+		op := opResp.JSON200.Data
+		if op.Status != nil {
+			tflog.Info(ctx, fmt.Sprintf("Operation %s status %s", operationId, *op.Status))
+			if *op.Status == missioncontrol.OperationStatusSUCCEEDED || *op.Status == missioncontrol.OperationStatusFAILED {
+				return &op
+			}
+		}
+	}
+}
+
+// extract error infos from an error response body, which is either a json ErrorResponse or a xml ErrorDTO
+func parseErrorResponse(body []byte) string {
+	var errResp missioncontrol.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Message == nil {
+		return parseErrorDTO(body)
+	}
+	errMsg := *errResp.Message
+	if errResp.ValidationDetails != nil {
+		errMsg = errMsg + fmt.Sprintf("\nValidation Error: %v", *errResp.ValidationDetails)
+	}
+	return errMsg
+}
+
+// plan modifier condition forcing a replacement when a configured value is decreased
+func requiresReplaceIfDecreased(_ context.Context, req planmodifier.Int32Request, resp *int32planmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+	resp.RequiresReplace = req.PlanValue.ValueInt32() < req.StateValue.ValueInt32()
+}
+
+// plan modifier condition forcing a replacement when a creation-only list changes, but not when it was
+// never stored in the state before (e.g. after an import)
+func requiresReplaceIfPreviouslySet(_ context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && len(req.StateValue.Elements()) > 0
+}
+
+// all protocols which can be enabled on a service connection endpoint
+var endpointProtocols = []string{
+	string(missioncontrol.ManagementSshTlsListenPort),
+	string(missioncontrol.ServiceAmqpPlainTextListenPort),
+	string(missioncontrol.ServiceAmqpTlsListenPort),
+	string(missioncontrol.ServiceManagementTlsListenPort),
+	string(missioncontrol.ServiceMqttPlainTextListenPort),
+	string(missioncontrol.ServiceMqttTlsListenPort),
+	string(missioncontrol.ServiceMqttTlsWebSocketListenPort),
+	string(missioncontrol.ServiceMqttWebSocketListenPort),
+	string(missioncontrol.ServiceRestIncomingPlainTextListenPort),
+	string(missioncontrol.ServiceRestIncomingTlsListenPort),
+	string(missioncontrol.ServiceSmfCompressedListenPort),
+	string(missioncontrol.ServiceSmfPlainTextListenPort),
+	string(missioncontrol.ServiceSmfTlsListenPort),
+	string(missioncontrol.ServiceWebPlainTextListenPort),
+	string(missioncontrol.ServiceWebTlsListenPort),
+}
+
+// helper to convert the configured ports (protocol -> port) to the api format
+func endpointPortsFromMap(ctx context.Context, ports types.Map, diagnostics *diag.Diagnostics) []missioncontrol.ServiceConnectionEndpointPort {
+	result := []missioncontrol.ServiceConnectionEndpointPort{}
+	portMap := map[string]int32{}
+	diagnostics.Append(ports.ElementsAs(ctx, &portMap, false)...)
+	for protocol, port := range portMap {
+		result = append(result, missioncontrol.ServiceConnectionEndpointPort{
+			Protocol: missioncontrol.ServiceConnectionEndpointPortProtocol(protocol),
+			Port:     &port,
+		})
+	}
+	// stable order for logging and testing
+	sort.Slice(result, func(i, j int) bool { return result[i].Protocol < result[j].Protocol })
+	return result
+}
+
+// connectionEndpointInfo maps a (computed) service connection endpoint as returned by the api.
+type connectionEndpointInfo struct {
+	ID         string                                `tfsdk:"id"`
+	Name       string                                `tfsdk:"name"`
+	AccessType string                                `tfsdk:"access_type"`
+	HostNames  []string                              `tfsdk:"hostnames"`
+	Ports      map[string]connectionEndpointPortInfo `tfsdk:"ports"`
+}
+
+// connectionEndpointPortInfo maps the port of a single protocol, port 0 means disabled.
+type connectionEndpointPortInfo struct {
+	Port    int32 `tfsdk:"port"`
+	Enabled bool  `tfsdk:"enabled"`
+}
+
+var connectionEndpointInfoType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":          types.StringType,
+		"name":        types.StringType,
+		"access_type": types.StringType,
+		"hostnames":   types.ListType{ElemType: types.StringType},
+		"ports": types.MapType{ElemType: types.ObjectType{
+			AttrTypes: map[string]attr.Type{
+				"port":    types.Int32Type,
+				"enabled": types.BoolType,
+			},
+		}},
+	},
+}
+
+// helper to convert all service connection endpoints of a service to a list value
+func connectionEndpointsValue(ctx context.Context, endpoints *[]missioncontrol.ConnectionEndpoint, diagnostics *diag.Diagnostics) types.List {
+	infos := []connectionEndpointInfo{}
+	if endpoints != nil {
+		for _, e := range *endpoints {
+			info := connectionEndpointInfo{
+				ID:         stringValue(e.Id),
+				Name:       e.Name,
+				AccessType: string(e.AccessType),
+				HostNames:  []string{},
+				Ports:      map[string]connectionEndpointPortInfo{},
+			}
+			if e.HostNames != nil {
+				info.HostNames = *e.HostNames
+			}
+			for _, p := range e.Ports {
+				var port int32
+				if p.Port != nil {
+					port = *p.Port
+				}
+				info.Ports[string(p.Protocol)] = connectionEndpointPortInfo{Port: port, Enabled: port != 0}
+			}
+			infos = append(infos, info)
+		}
+	}
+	result, diags := types.ListValueFrom(ctx, connectionEndpointInfoType, infos)
+	diagnostics.Append(diags...)
+	return result
+}
+
+// helper returning "" for nil strings
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// helper waiting for a started operation to succeed, a failed or vanished operation is reported as error with the given summary
+func waitForOperationSuccess(ctx context.Context, pd CMProviderData, reqEditor missioncontrol.RequestEditorFn, serviceId string, operationId string, timeout time.Duration, summary string, diagnostics *diag.Diagnostics) {
+	op := waitForServiceOperation(ctx, pd, reqEditor, serviceId, operationId, timeout, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	if op == nil {
+		diagnostics.AddError(
+			summary,
+			fmt.Sprintf("Could not find operation %s of broker service %s", operationId, serviceId),
+		)
+		return
+	}
+	if *op.Status == missioncontrol.OperationStatusFAILED {
+		diagnostics.AddError(
+			summary,
+			operationErrorMessage(op),
+		)
+	}
+}
+
+// helper deciding whether a paged list has more results, based on meta.pagination.nextPage.
+// Without pagination info a full page is taken as a hint for more results
+func hasNextPage(meta map[string]map[string]interface{}, count int, pageSize int) bool {
+	if pagination, ok := meta["pagination"]; ok {
+		return pagination["nextPage"] != nil
+	}
+	return count > 0 && count == pageSize
+}
+
+// helper telling whether a plan value is given, i.e. neither null nor unknown
+func known(v attr.Value) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+// sempBasicAuthProbeTimeout limits the probe of the management endpoint of a broker service
+const sempBasicAuthProbeTimeout = 10 * time.Second
+
+// helper returning the management url (https://host:port) of the first endpoint with an enabled management port, "" if there is none
+func managementURL(ctx context.Context, endpoints types.List, diagnostics *diag.Diagnostics) string {
+	if !known(endpoints) {
+		return ""
+	}
+	var infos []connectionEndpointInfo
+	diagnostics.Append(endpoints.ElementsAs(ctx, &infos, false)...)
+	for _, info := range infos {
+		port, ok := info.Ports[string(missioncontrol.ServiceManagementTlsListenPort)]
+		if ok && port.Enabled && len(info.HostNames) > 0 {
+			return "https://" + net.JoinHostPort(info.HostNames[0], fmt.Sprint(port.Port))
+		}
+	}
+	return ""
+}
+
+// helper probing whether the management endpoint of a broker service accepts basic authentication, as mission control
+// only allows to set it. A 401 is only conclusive if the broker does not offer basic authentication anymore, otherwise
+// the credentials may just be wrong or outdated. Returns false for ok if the answer is inconclusive, e.g. the endpoint
+// is not reachable from here
+func probeSempBasicAuth(ctx context.Context, httpClient *http.Client, managementURL string, username string, password string) (enabled bool, ok bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, managementURL+"/SEMP/v2/config/about/user", nil)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not probe basic authentication of %s: %s", managementURL, err))
+		return false, false
+	}
+	req.SetBasicAuth(username, password)
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not probe basic authentication of %s: %s", managementURL, err))
+		return false, false
+	}
+	_ = httpResp.Body.Close()
+	tflog.Debug(ctx, fmt.Sprintf("Basic authentication probe of %s: %v", managementURL, httpResp.StatusCode))
+	switch httpResp.StatusCode {
+	case http.StatusOK:
+		return true, true
+	case http.StatusUnauthorized:
+		challenges := httpResp.Header.Values("WWW-Authenticate")
+		if len(challenges) == 0 {
+			return false, false
+		}
+		for _, challenge := range challenges {
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(challenge)), "basic") {
+				tflog.Warn(ctx, fmt.Sprintf("Basic authentication of %s rejected the admin credentials, keeping the state", managementURL))
+				return false, false
+			}
+		}
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// helper reading the broker state (high availability, redundancy and active node) of a service, nil if the service does not exist
+func getBrokerState(ctx context.Context, pd CMProviderData, reqEditor missioncontrol.RequestEditorFn, serviceId string, diagnostics *diag.Diagnostics) *missioncontrol.BrokerState {
+	stateResp, err := pd.Client.GetBrokerStateByServiceIdWithResponse(ctx, serviceId, reqEditor)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting broker state",
+			"Could not get broker state, unexpected error: "+err.Error(),
+		)
+		return nil
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(stateResp.Body)))
+	if stateResp.StatusCode() == 404 {
+		return nil
+	}
+	if stateResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting broker state",
+			fmt.Sprintf("Unexpected response code: %v\n%s", stateResp.StatusCode(), parseErrorResponse(stateResp.Body)),
+		)
+		return nil
+	}
+	return &stateResp.JSON200.Data
+}
+
+// helper getting the creation state of a broker service, "" if the service does not exist
+func getServiceCreationState(ctx context.Context, pd CMProviderData, reqEditor missioncontrol.RequestEditorFn, serviceId string, diagnostics *diag.Diagnostics) string {
+	getResp, err := pd.Client.GetServiceWithResponse(ctx, serviceId, nil, reqEditor)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting broker service",
+			"Could not get broker service, unexpected error: "+err.Error(),
+		)
+		return ""
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return ""
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting broker service",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return ""
+	}
+	if getResp.JSON200 == nil || getResp.JSON200.Data.CreationState == nil {
+		return ""
+	}
+	return string(*getResp.JSON200.Data.CreationState)
+}
+
+// helper returning the active node of a broker state, "" if it is not highly available
+func activeNode(state *missioncontrol.BrokerState) string {
+	if state == nil || state.Redundancy == nil || state.Redundancy.ActiveNode == nil {
+		return ""
+	}
+	return string(*state.Redundancy.ActiveNode)
+}
+
+// helper comparing event broker versions like 10.8.1.152 numerically, returns -1, 0 or 1
+func compareBrokerVersions(a string, b string) int {
+	isSeparator := func(r rune) bool { return r == '.' || r == '-' }
+	as := strings.FieldsFunc(a, isSeparator)
+	bs := strings.FieldsFunc(b, isSeparator)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionDowngradeModifier rejects a planned event broker version older than the current one, brokers can only be upgraded.
+type versionDowngradeModifier struct{}
+
+func (m versionDowngradeModifier) Description(_ context.Context) string {
+	return "the event broker version can't be downgraded"
+}
+
+func (m versionDowngradeModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m versionDowngradeModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !known(req.PlanValue) || !known(req.StateValue) {
+		return
+	}
+	if compareBrokerVersions(req.PlanValue.ValueString(), req.StateValue.ValueString()) < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Event Broker Version",
+			fmt.Sprintf("Attribute %s: %s from %s to %s", req.Path, m.Description(ctx), req.StateValue.ValueString(), req.PlanValue.ValueString()),
+		)
+	}
+}
+
+// helper reading an upgrade (maintenance activity) of a service, nil if it does not exist
+func getUpgradeActivity(ctx context.Context, pd CMProviderData, reqEditor missioncontrol.RequestEditorFn, serviceId string, activityId string, diagnostics *diag.Diagnostics) *missioncontrol.MaintenanceActivity {
+	getResp, err := pd.Client.GetEventBrokerServiceUpgradeWithResponse(ctx, serviceId, activityId, reqEditor)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting broker upgrade",
+			"Could not get broker upgrade, unexpected error: "+err.Error(),
+		)
+		return nil
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return nil
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting broker upgrade",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return nil
+	}
+	// the api answers with a list
+	if getResp.JSON200.Data != nil {
+		for _, activity := range *getResp.JSON200.Data {
+			if activity.Id != nil && *activity.Id == activityId {
+				return &activity
+			}
+		}
+	}
+	return nil
+}
+
+// helper returning the combined status of a maintenance activity
+func maintenanceActivityStatus(activity *missioncontrol.MaintenanceActivity) string {
+	if activity.MaintenanceActivityStatus == nil {
+		return ""
+	}
+	return string(*activity.MaintenanceActivityStatus)
+}
+
+// helper extracting the error infos of a failed maintenance activity
+func maintenanceActivityMessage(activity *missioncontrol.MaintenanceActivity) string {
+	message := fmt.Sprintf("Maintenance activity %s has status %s", stringValue(activity.Id), maintenanceActivityStatus(activity))
+	if activity.Details != nil && *activity.Details != "" {
+		message += "\n" + *activity.Details
+	}
+	if activity.MaintenanceLogs != nil {
+		message += "\n" + strings.Join(*activity.MaintenanceLogs, "\n")
+	}
+	return message
+}
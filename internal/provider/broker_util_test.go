@@ -1,8 +1,16 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,3 +22,182 @@ func TestGetRouterPrefix1(t *testing.T) {
 	assert.Equal(t, "test123", getRouterPrefix("test123backup"), "backup suffix")
 	assert.Equal(t, "test123unexpected", getRouterPrefix("test123unexpected"), "not matching")
 }
+
+func TestOperationErrorMessage(t *testing.T) {
+	opId := "op1"
+	errId := "err42"
+	msg := "something went wrong"
+	assert.Equal(t, "Operation op1 failed without further details",
+		operationErrorMessage(&missioncontrol.Operation{Id: &opId}), "no error details")
+	assert.Equal(t, "Operation op1 failed: something went wrong",
+		operationErrorMessage(&missioncontrol.Operation{Id: &opId, Error: &missioncontrol.OperationError{Message: &msg}}), "message only")
+	assert.Equal(t, "Operation op1 failed: something went wrong\nErrorId: err42",
+		operationErrorMessage(&missioncontrol.Operation{Id: &opId, Error: &missioncontrol.OperationError{Message: &msg, ErrorId: &errId}}), "message and errorId")
+}
+
+func TestParseErrorResponse(t *testing.T) {
+	assert.Equal(t, "invalid spool size",
+		parseErrorResponse([]byte(`{"message":"invalid spool size","errorId":"42"}`)), "json message")
+	assert.Equal(t, "invalid request\nValidation Error: map[name:[must not be empty]]",
+		parseErrorResponse([]byte(`{"message":"invalid request","validationDetails":{"name":["must not be empty"]}}`)), "json validation details")
+	assert.Equal(t, "Message: invalid request\nValidationDetails: details\n",
+		parseErrorResponse([]byte(`<ErrorDTO><message>invalid request</message><validationDetails>details</validationDetails></ErrorDTO>`)), "xml ErrorDTO")
+}
+
+func TestConnectionEndpointsValue(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	empty := connectionEndpointsValue(ctx, nil, &diags)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, empty.Elements(), "no endpoints")
+
+	id := "ep1"
+	smfPort := int32(55443)
+	disabledPort := int32(0)
+	endpoints := []missioncontrol.ConnectionEndpoint{{
+		Id:         &id,
+		Name:       "private",
+		AccessType: missioncontrol.PRIVATE,
+		HostNames:  &[]string{"host1"},
+		Ports: []missioncontrol.ServiceConnectionEndpointPort{
+			{Protocol: missioncontrol.ServiceSmfTlsListenPort, Port: &smfPort},
+			{Protocol: missioncontrol.ServiceSmfPlainTextListenPort, Port: &disabledPort},
+		},
+	}}
+	value := connectionEndpointsValue(ctx, &endpoints, &diags)
+	assert.False(t, diags.HasError())
+
+	var infos []connectionEndpointInfo
+	diags.Append(value.ElementsAs(ctx, &infos, false)...)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []connectionEndpointInfo{{
+		ID:         "ep1",
+		Name:       "private",
+		AccessType: "PRIVATE",
+		HostNames:  []string{"host1"},
+		Ports: map[string]connectionEndpointPortInfo{
+			"serviceSmfTlsListenPort":       {Port: 55443, Enabled: true},
+			"serviceSmfPlainTextListenPort": {Port: 0, Enabled: false},
+		},
+	}}, infos)
+}
+
+func TestHasNextPage(t *testing.T) {
+	assert.True(t, hasNextPage(map[string]map[string]interface{}{"pagination": {"nextPage": float64(2)}}, 100, 100), "next page given")
+	assert.False(t, hasNextPage(map[string]map[string]interface{}{"pagination": {"nextPage": nil}}, 100, 100), "last page")
+	assert.True(t, hasNextPage(nil, 100, 100), "full page without pagination info")
+	assert.False(t, hasNextPage(nil, 42, 100), "partial page without pagination info")
+	assert.False(t, hasNextPage(nil, 0, 0), "empty page")
+}
+
+func TestProbeSempBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); ok && username == "admin" && password == "secret" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if username, _, ok := r.BasicAuth(); ok && username == "oauth-only" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="semp"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if username, _, ok := r.BasicAuth(); ok && username == "admin" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="semp"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	enabled, ok := probeSempBasicAuth(context.Background(), server.Client(), server.URL, "admin", "secret")
+	assert.True(t, ok)
+	assert.True(t, enabled, "accepted credentials")
+	enabled, ok = probeSempBasicAuth(context.Background(), server.Client(), server.URL, "oauth-only", "secret")
+	assert.True(t, ok)
+	assert.False(t, enabled, "basic authentication not offered")
+	_, ok = probeSempBasicAuth(context.Background(), server.Client(), server.URL, "admin", "wrong")
+	assert.False(t, ok, "rejected credentials")
+	_, ok = probeSempBasicAuth(context.Background(), server.Client(), server.URL, "someone", "else")
+	assert.False(t, ok, "401 without challenge")
+	_, ok = probeSempBasicAuth(context.Background(), server.Client(), "http://localhost:0", "admin", "secret")
+	assert.False(t, ok, "unreachable endpoint")
+}
+
+func TestRefreshSempBasicAuth(t *testing.T) {
+	ctx := context.Background()
+	challenge := `Bearer realm="semp"`
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", challenge)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	host := serverURL.Hostname()
+	port, err := strconv.ParseInt(serverURL.Port(), 10, 32)
+	assert.NoError(t, err)
+	mgmtPort := int32(port)
+	var diags diag.Diagnostics
+	model := brokerResourceModel{
+		MgmtAdminUserName:    types.StringValue("admin"),
+		MgmtAdminPassword:    types.StringValue("secret"),
+		SempBasicAuthEnabled: types.BoolValue(true),
+		AllConnectionEndpoints: connectionEndpointsValue(ctx, &[]missioncontrol.ConnectionEndpoint{
+			{Name: "mgmt", HostNames: &[]string{host}, Ports: []missioncontrol.ServiceConnectionEndpointPort{
+				{Protocol: missioncontrol.ServiceManagementTlsListenPort, Port: &mgmtPort},
+			}},
+		}, &diags),
+	}
+
+	// rejected credentials keep the state
+	challenge = `Basic realm="semp"`
+	refreshSempBasicAuth(ctx, server.Client(), &model, &diags)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, types.BoolValue(true), model.SempBasicAuthEnabled, "inconclusive probe")
+
+	// basic authentication disabled outside of terraform is drift
+	challenge = `Bearer realm="semp"`
+	refreshSempBasicAuth(ctx, server.Client(), &model, &diags)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, types.BoolValue(false), model.SempBasicAuthEnabled, "drift detected")
+}
+
+func TestManagementURL(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	smfPort, mgmtPort := int32(55443), int32(943)
+	endpoints := connectionEndpointsValue(ctx, &[]missioncontrol.ConnectionEndpoint{
+		{Name: "smf", HostNames: &[]string{"smf.host"}, Ports: []missioncontrol.ServiceConnectionEndpointPort{
+			{Protocol: missioncontrol.ServiceSmfTlsListenPort, Port: &smfPort},
+		}},
+		{Name: "mgmt", HostNames: &[]string{"mgmt.host"}, Ports: []missioncontrol.ServiceConnectionEndpointPort{
+			{Protocol: missioncontrol.ServiceManagementTlsListenPort, Port: &mgmtPort},
+		}},
+	}, &diags)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "https://mgmt.host:943", managementURL(ctx, endpoints, &diags))
+	assert.Equal(t, "", managementURL(ctx, types.ListNull(connectionEndpointInfoType), &diags))
+}
+
+func TestCompareBrokerVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"10.8.1.152", "10.8.1.152", 0},
+		{"10.8.1.152", "10.9.0.40", -1},
+		{"10.10.0.1", "10.9.0.40", 1},
+		{"10.8.1", "10.8.1.0", 0},
+		{"10.8.1.152-7", "10.8.1.152-6", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, compareBrokerVersions(tt.a, tt.b), "compare %s to %s", tt.a, tt.b)
+	}
+}
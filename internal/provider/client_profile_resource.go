@@ -0,0 +1,974 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clientProfileResourceModel maps the resource schema data.
+type clientProfileResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ServiceId types.String `tfsdk:"service_id"`
+	Name      types.String `tfsdk:"name"`
+	clientProfileSettingsModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// clientProfileSettingsModel maps the settings of a client profile, shared by the resource and the data source.
+// The deprecated *CopyFromOnCreateName settings are left out in favor of their template counterparts
+type clientProfileSettingsModel struct {
+	AllowBridgeConnectionsEnabled                                 types.Bool   `tfsdk:"allow_bridge_connections_enabled"`
+	AllowGuaranteedEndpointCreateEnabled                          types.Bool   `tfsdk:"allow_guaranteed_endpoint_create_enabled"`
+	AllowGuaranteedMsgReceiveEnabled                              types.Bool   `tfsdk:"allow_guaranteed_msg_receive_enabled"`
+	AllowGuaranteedMsgSendEnabled                                 types.Bool   `tfsdk:"allow_guaranteed_msg_send_enabled"`
+	AllowSharedSubscriptionsEnabled                               types.Bool   `tfsdk:"allow_shared_subscriptions_enabled"`
+	AllowTransactedSessionsEnabled                                types.Bool   `tfsdk:"allow_transacted_sessions_enabled"`
+	ApiQueueManagementCopyFromOnCreateTemplateName                types.String `tfsdk:"api_queue_management_copy_from_on_create_template_name"`
+	ApiTopicEndpointManagementCopyFromOnCreateTemplateName        types.String `tfsdk:"api_topic_endpoint_management_copy_from_on_create_template_name"`
+	CompressionEnabled                                            types.Bool   `tfsdk:"compression_enabled"`
+	ElidingDelay                                                  types.Int32  `tfsdk:"eliding_delay"`
+	ElidingEnabled                                                types.Bool   `tfsdk:"eliding_enabled"`
+	ElidingMaxTopicCount                                          types.Int32  `tfsdk:"eliding_max_topic_count"`
+	EventClientProvisionedEndpointSpoolUsageThresholdClearPercent types.Int32  `tfsdk:"event_client_provisioned_endpoint_spool_usage_threshold_clear_percent"`
+	EventClientProvisionedEndpointSpoolUsageThresholdSetPercent   types.Int32  `tfsdk:"event_client_provisioned_endpoint_spool_usage_threshold_set_percent"`
+	MaxConnectionCountPerClientUsername                           types.Int32  `tfsdk:"max_connection_count_per_client_username"`
+	MaxEgressFlowCount                                            types.Int32  `tfsdk:"max_egress_flow_count"`
+	MaxEndpointCountPerClientUsername                             types.Int32  `tfsdk:"max_endpoint_count_per_client_username"`
+	MaxIngressFlowCount                                           types.Int32  `tfsdk:"max_ingress_flow_count"`
+	MaxSubscriptionCount                                          types.Int32  `tfsdk:"max_subscription_count"`
+	MaxTransactedSessionCount                                     types.Int32  `tfsdk:"max_transacted_session_count"`
+	MaxTransactionCount                                           types.Int32  `tfsdk:"max_transaction_count"`
+	QueueControl1MaxDepth                                         types.Int32  `tfsdk:"queue_control_1_max_depth"`
+	QueueControl1MinMsgBurst                                      types.Int32  `tfsdk:"queue_control_1_min_msg_burst"`
+	QueueDirect1MaxDepth                                          types.Int32  `tfsdk:"queue_direct_1_max_depth"`
+	QueueDirect1MinMsgBurst                                       types.Int32  `tfsdk:"queue_direct_1_min_msg_burst"`
+	QueueDirect2MaxDepth                                          types.Int32  `tfsdk:"queue_direct_2_max_depth"`
+	QueueDirect2MinMsgBurst                                       types.Int32  `tfsdk:"queue_direct_2_min_msg_burst"`
+	QueueDirect3MaxDepth                                          types.Int32  `tfsdk:"queue_direct_3_max_depth"`
+	QueueDirect3MinMsgBurst                                       types.Int32  `tfsdk:"queue_direct_3_min_msg_burst"`
+	QueueGuaranteed1MaxDepth                                      types.Int32  `tfsdk:"queue_guaranteed_1_max_depth"`
+	QueueGuaranteed1MinMsgBurst                                   types.Int32  `tfsdk:"queue_guaranteed_1_min_msg_burst"`
+	RejectMsgToSenderOnNoSubscriptionMatchEnabled                 types.Bool   `tfsdk:"reject_msg_to_sender_on_no_subscription_match_enabled"`
+	ReplicationAllowClientConnectWhenStandbyEnabled               types.Bool   `tfsdk:"replication_allow_client_connect_when_standby_enabled"`
+	ServiceMinKeepaliveTimeout                                    types.Int32  `tfsdk:"service_min_keepalive_timeout"`
+	ServiceSmfMaxConnectionCountPerClientUsername                 types.Int32  `tfsdk:"service_smf_max_connection_count_per_client_username"`
+	ServiceSmfMinKeepaliveEnabled                                 types.Bool   `tfsdk:"service_smf_min_keepalive_enabled"`
+	ServiceWebInactiveTimeout                                     types.Int32  `tfsdk:"service_web_inactive_timeout"`
+	ServiceWebMaxConnectionCountPerClientUsername                 types.Int32  `tfsdk:"service_web_max_connection_count_per_client_username"`
+	ServiceWebMaxPayload                                          types.Int32  `tfsdk:"service_web_max_payload"`
+	TcpCongestionWindowSize                                       types.Int32  `tfsdk:"tcp_congestion_window_size"`
+	TcpKeepaliveCount                                             types.Int32  `tfsdk:"tcp_keepalive_count"`
+	TcpKeepaliveIdleTime                                          types.Int32  `tfsdk:"tcp_keepalive_idle_time"`
+	TcpKeepaliveInterval                                          types.Int32  `tfsdk:"tcp_keepalive_interval"`
+	TcpMaxSegmentSize                                             types.Int32  `tfsdk:"tcp_max_segment_size"`
+	TcpMaxWindowSize                                              types.Int32  `tfsdk:"tcp_max_window_size"`
+	TlsAllowDowngradeToPlainTextEnabled                           types.Bool   `tfsdk:"tls_allow_downgrade_to_plain_text_enabled"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clientProfileResource{}
+	_ resource.ResourceWithConfigure   = &clientProfileResource{}
+	_ resource.ResourceWithImportState = &clientProfileResource{}
+)
+
+// NewClientProfileResource is a helper function to simplify the provider implementation.
+func NewClientProfileResource() resource.Resource {
+	return &clientProfileResource{}
+}
+
+// clientProfileResource is the resource implementation.
+type clientProfileResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *clientProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_profile"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clientProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure client profile resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *clientProfileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := clientProfileSettingsAttributes()
+	attributes["service_id"] = schema.StringAttribute{
+		MarkdownDescription: "The id of the broker service",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the client profile",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	// computed attributes
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The id of the client profile",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Client profile of an existing broker service. Settings which are not configured keep the value of the broker. Import using *service_id/profile_name*",
+		Attributes:          attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *clientProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState clientProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	body := clientProfileSettings(plannedState.clientProfileSettingsModel, nil)
+	body.Name = plannedState.Name.ValueStringPointer()
+	tflog.Info(ctx, fmt.Sprintf("Creating client profile %s", plannedState.Name.ValueString()))
+
+	createResp, err := r.cMProviderData.Client.CreateClientProfileWithResponse(ctx, serviceId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating client profile",
+			"Could not create client profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(createResp.Body)))
+	if createResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error creating client profile",
+			fmt.Sprintf("Unexpected response code: %v\n%s", createResp.StatusCode(), parseErrorResponse(createResp.Body)),
+		)
+		return
+	}
+
+	// store the keys right away, so a failed or timed out creation leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), plannedState.ServiceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), plannedState.Name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(createResp.JSON202.Data.Id), createTimeout, "Error creating client profile", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error creating client profile",
+			fmt.Sprintf("Client profile %s of broker service %s vanished", plannedState.Name.ValueString(), serviceId),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *clientProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState clientProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clientProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState clientProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	name := plannedState.Name.ValueString()
+	// only send what has changed
+	body := clientProfileSettings(plannedState.clientProfileSettingsModel, &currentState.clientProfileSettingsModel)
+	if body != (missioncontrol.ClientProfileRequest{}) {
+		tflog.Info(ctx, fmt.Sprintf("Updating client profile %s", name))
+		updateResp, err := r.cMProviderData.Client.UpdateClientProfileWithResponse(ctx, serviceId, name, body, r.cMProviderData.BearerReqEditorFn)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating client profile",
+				"Could not update client profile, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(updateResp.Body)))
+		if updateResp.StatusCode() != 202 {
+			resp.Diagnostics.AddError(
+				"Error updating client profile",
+				fmt.Sprintf("Unexpected response code: %v\n%s", updateResp.StatusCode(), parseErrorResponse(updateResp.Body)),
+			)
+			return
+		}
+		waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(updateResp.JSON202.Data.Id), updateTimeout, "Error updating client profile", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error updating client profile",
+			fmt.Sprintf("Client profile %s of broker service %s vanished", name, serviceId),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *clientProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState clientProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := currentState.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := currentState.ServiceId.ValueString()
+	name := currentState.Name.ValueString()
+	delResp, err := r.cMProviderData.Client.DeleteClientProfileWithResponse(ctx, serviceId, name, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting client profile",
+			"Could not delete client profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
+	if delResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find client profile %s of broker service %s", name, serviceId))
+		// this is tolerable!
+		return
+	}
+	if delResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error deleting client profile",
+			fmt.Sprintf("Unexpected response code: %v\n%s", delResp.StatusCode(), parseErrorResponse(delResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(delResp.JSON202.Data.Id), deleteTimeout, "Error deleting client profile", &resp.Diagnostics)
+}
+
+// ImportState imports a client profile using service_id/profile_name.
+func (r *clientProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceId, name, ok := strings.Cut(req.ID, "/")
+	if !ok || serviceId == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// helper reading the client profile into the model, returns false if it does not exist
+func (r *clientProfileResource) get(ctx context.Context, model *clientProfileResourceModel, diagnostics *diag.Diagnostics) bool {
+	getResp, err := r.cMProviderData.Client.GetClientProfileWithResponse(ctx, model.ServiceId.ValueString(), model.Name.ValueString(), r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting client profile",
+			"Could not get client profile, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return false
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting client profile",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return false
+	}
+
+	profile := getResp.JSON200.Data
+	model.ID = types.StringPointerValue(profile.Id)
+	model.Name = types.StringValue(profile.Name)
+	clientProfileToModel(profile, &model.clientProfileSettingsModel)
+	return true
+}
+
+// clientProfileSettingsAttributes defines the schema of the client profile settings.
+// Settings not configured keep the value of the server, so its defaults do not cause diffs
+func clientProfileSettingsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"allow_bridge_connections_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to establish bridge (DMR) links to another Message VPN",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"allow_guaranteed_endpoint_create_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to create queues or topic endpoints",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"allow_guaranteed_msg_receive_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to bind to queues or topic endpoints to receive guaranteed messages",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"allow_guaranteed_msg_send_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to publish guaranteed messages",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"allow_shared_subscriptions_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to use shared subscriptions",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"allow_transacted_sessions_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to establish transacted sessions or XA sessions",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"api_queue_management_copy_from_on_create_template_name": schema.StringAttribute{
+			MarkdownDescription: "The queue template to copy settings from when a client creates a queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"api_topic_endpoint_management_copy_from_on_create_template_name": schema.StringAttribute{
+			MarkdownDescription: "The topic endpoint template to copy settings from when a client creates a topic endpoint",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"compression_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to transfer data using compression",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"eliding_delay": schema.Int32Attribute{
+			MarkdownDescription: "The delay (in milliseconds) of the delivery of messages after the initial message has been delivered",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"eliding_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients are allowed to use eliding",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"eliding_max_topic_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of topics tracked for eliding per client connection",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"event_client_provisioned_endpoint_spool_usage_threshold_clear_percent": schema.Int32Attribute{
+			MarkdownDescription: "The clear threshold (in percent) of the event on the spool usage of client provisioned endpoints",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int32{
+				int32validator.Between(0, 100),
+			},
+		},
+		"event_client_provisioned_endpoint_spool_usage_threshold_set_percent": schema.Int32Attribute{
+			MarkdownDescription: "The set threshold (in percent) of the event on the spool usage of client provisioned endpoints",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int32{
+				int32validator.Between(0, 100),
+			},
+		},
+		"max_connection_count_per_client_username": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of simultaneous client connections using the same client username",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_egress_flow_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of egress (consumer) flows per client",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_endpoint_count_per_client_username": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of queues and topic endpoints owned by clients using the same client username",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_ingress_flow_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of ingress (publish) flows per client",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_subscription_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of subscriptions per client",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_transacted_session_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of simultaneous transacted sessions and XA sessions per client",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_transaction_count": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of simultaneous transactions per client",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_control_1_max_depth": schema.Int32Attribute{
+			MarkdownDescription: "The maximum depth (in work units) of the Control 1 egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_control_1_min_msg_burst": schema.Int32Attribute{
+			MarkdownDescription: "The number of messages always allowed on the Control 1 egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_1_max_depth": schema.Int32Attribute{
+			MarkdownDescription: "The maximum depth (in work units) of the Direct 1 (COS 1) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_1_min_msg_burst": schema.Int32Attribute{
+			MarkdownDescription: "The number of messages always allowed on the Direct 1 (COS 1) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_2_max_depth": schema.Int32Attribute{
+			MarkdownDescription: "The maximum depth (in work units) of the Direct 2 (COS 2) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_2_min_msg_burst": schema.Int32Attribute{
+			MarkdownDescription: "The number of messages always allowed on the Direct 2 (COS 2) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_3_max_depth": schema.Int32Attribute{
+			MarkdownDescription: "The maximum depth (in work units) of the Direct 3 (COS 3) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_direct_3_min_msg_burst": schema.Int32Attribute{
+			MarkdownDescription: "The number of messages always allowed on the Direct 3 (COS 3) egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_guaranteed_1_max_depth": schema.Int32Attribute{
+			MarkdownDescription: "The maximum depth (in work units) of the Guaranteed 1 egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"queue_guaranteed_1_min_msg_burst": schema.Int32Attribute{
+			MarkdownDescription: "The number of messages always allowed on the Guaranteed 1 egress queue",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"reject_msg_to_sender_on_no_subscription_match_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether guaranteed messages without a matching subscription are rejected (NACKed) to the sender",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"replication_allow_client_connect_when_standby_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients may stay connected to the Message VPN while its replication state is standby",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_min_keepalive_timeout": schema.Int32Attribute{
+			MarkdownDescription: "The minimum time (in seconds) of inactivity tolerated on a client connection",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_smf_max_connection_count_per_client_username": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of simultaneous SMF connections using the same client username",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_smf_min_keepalive_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether the minimum keepalive timeout is enforced for SMF connections",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_web_inactive_timeout": schema.Int32Attribute{
+			MarkdownDescription: "The time (in seconds) a web client may be inactive before its session is terminated",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_web_max_connection_count_per_client_username": schema.Int32Attribute{
+			MarkdownDescription: "The maximum number of simultaneous web transport connections using the same client username",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"service_web_max_payload": schema.Int32Attribute{
+			MarkdownDescription: "The maximum web transport payload (in bytes) before fragmentation",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"tcp_congestion_window_size": schema.Int32Attribute{
+			MarkdownDescription: "The TCP initial congestion window size (in segments)",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"tcp_keepalive_count": schema.Int32Attribute{
+			MarkdownDescription: "The number of TCP keepalive probes sent before dropping the connection (2 to 5)",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int32{
+				int32validator.Between(2, 5),
+			},
+		},
+		"tcp_keepalive_idle_time": schema.Int32Attribute{
+			MarkdownDescription: "The idle time (3 to 120 seconds) before TCP starts sending keepalive probes",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int32{
+				int32validator.Between(3, 120),
+			},
+		},
+		"tcp_keepalive_interval": schema.Int32Attribute{
+			MarkdownDescription: "The interval (1 to 30 seconds) between TCP keepalive probes",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int32{
+				int32validator.Between(1, 30),
+			},
+		},
+		"tcp_max_segment_size": schema.Int32Attribute{
+			MarkdownDescription: "The TCP maximum segment size (in bytes)",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"tcp_max_window_size": schema.Int32Attribute{
+			MarkdownDescription: "The TCP maximum window size (in KB)",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int32{
+				int32planmodifier.UseStateForUnknown(),
+			},
+		},
+		"tls_allow_downgrade_to_plain_text_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether clients may downgrade a TLS connection to plain text after authentication",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+// helper collecting the known settings of the plan. If a prior state is given, only the changed settings are collected
+func clientProfileSettings(plan clientProfileSettingsModel, prior *clientProfileSettingsModel) missioncontrol.ClientProfileRequest {
+	var settings missioncontrol.ClientProfileRequest
+	var current clientProfileSettingsModel
+	if prior != nil {
+		current = *prior
+	}
+	// unknown values are not configured and taken from the broker
+	if known(plan.AllowBridgeConnectionsEnabled) && (prior == nil || !plan.AllowBridgeConnectionsEnabled.Equal(current.AllowBridgeConnectionsEnabled)) {
+		settings.AllowBridgeConnectionsEnabled = plan.AllowBridgeConnectionsEnabled.ValueBoolPointer()
+	}
+	if known(plan.AllowGuaranteedEndpointCreateEnabled) && (prior == nil || !plan.AllowGuaranteedEndpointCreateEnabled.Equal(current.AllowGuaranteedEndpointCreateEnabled)) {
+		settings.AllowGuaranteedEndpointCreateEnabled = plan.AllowGuaranteedEndpointCreateEnabled.ValueBoolPointer()
+	}
+	if known(plan.AllowGuaranteedMsgReceiveEnabled) && (prior == nil || !plan.AllowGuaranteedMsgReceiveEnabled.Equal(current.AllowGuaranteedMsgReceiveEnabled)) {
+		settings.AllowGuaranteedMsgReceiveEnabled = plan.AllowGuaranteedMsgReceiveEnabled.ValueBoolPointer()
+	}
+	if known(plan.AllowGuaranteedMsgSendEnabled) && (prior == nil || !plan.AllowGuaranteedMsgSendEnabled.Equal(current.AllowGuaranteedMsgSendEnabled)) {
+		settings.AllowGuaranteedMsgSendEnabled = plan.AllowGuaranteedMsgSendEnabled.ValueBoolPointer()
+	}
+	if known(plan.AllowSharedSubscriptionsEnabled) && (prior == nil || !plan.AllowSharedSubscriptionsEnabled.Equal(current.AllowSharedSubscriptionsEnabled)) {
+		settings.AllowSharedSubscriptionsEnabled = plan.AllowSharedSubscriptionsEnabled.ValueBoolPointer()
+	}
+	if known(plan.AllowTransactedSessionsEnabled) && (prior == nil || !plan.AllowTransactedSessionsEnabled.Equal(current.AllowTransactedSessionsEnabled)) {
+		settings.AllowTransactedSessionsEnabled = plan.AllowTransactedSessionsEnabled.ValueBoolPointer()
+	}
+	if known(plan.ApiQueueManagementCopyFromOnCreateTemplateName) && (prior == nil || !plan.ApiQueueManagementCopyFromOnCreateTemplateName.Equal(current.ApiQueueManagementCopyFromOnCreateTemplateName)) {
+		settings.ApiQueueManagementCopyFromOnCreateTemplateName = plan.ApiQueueManagementCopyFromOnCreateTemplateName.ValueStringPointer()
+	}
+	if known(plan.ApiTopicEndpointManagementCopyFromOnCreateTemplateName) && (prior == nil || !plan.ApiTopicEndpointManagementCopyFromOnCreateTemplateName.Equal(current.ApiTopicEndpointManagementCopyFromOnCreateTemplateName)) {
+		settings.ApiTopicEndpointManagementCopyFromOnCreateTemplateName = plan.ApiTopicEndpointManagementCopyFromOnCreateTemplateName.ValueStringPointer()
+	}
+	if known(plan.CompressionEnabled) && (prior == nil || !plan.CompressionEnabled.Equal(current.CompressionEnabled)) {
+		settings.CompressionEnabled = plan.CompressionEnabled.ValueBoolPointer()
+	}
+	if known(plan.ElidingDelay) && (prior == nil || !plan.ElidingDelay.Equal(current.ElidingDelay)) {
+		settings.ElidingDelay = plan.ElidingDelay.ValueInt32Pointer()
+	}
+	if known(plan.ElidingEnabled) && (prior == nil || !plan.ElidingEnabled.Equal(current.ElidingEnabled)) {
+		settings.ElidingEnabled = plan.ElidingEnabled.ValueBoolPointer()
+	}
+	if known(plan.ElidingMaxTopicCount) && (prior == nil || !plan.ElidingMaxTopicCount.Equal(current.ElidingMaxTopicCount)) {
+		settings.ElidingMaxTopicCount = plan.ElidingMaxTopicCount.ValueInt32Pointer()
+	}
+	if known(plan.MaxConnectionCountPerClientUsername) && (prior == nil || !plan.MaxConnectionCountPerClientUsername.Equal(current.MaxConnectionCountPerClientUsername)) {
+		settings.MaxConnectionCountPerClientUsername = plan.MaxConnectionCountPerClientUsername.ValueInt32Pointer()
+	}
+	if known(plan.MaxEgressFlowCount) && (prior == nil || !plan.MaxEgressFlowCount.Equal(current.MaxEgressFlowCount)) {
+		settings.MaxEgressFlowCount = plan.MaxEgressFlowCount.ValueInt32Pointer()
+	}
+	if known(plan.MaxEndpointCountPerClientUsername) && (prior == nil || !plan.MaxEndpointCountPerClientUsername.Equal(current.MaxEndpointCountPerClientUsername)) {
+		settings.MaxEndpointCountPerClientUsername = plan.MaxEndpointCountPerClientUsername.ValueInt32Pointer()
+	}
+	if known(plan.MaxIngressFlowCount) && (prior == nil || !plan.MaxIngressFlowCount.Equal(current.MaxIngressFlowCount)) {
+		settings.MaxIngressFlowCount = plan.MaxIngressFlowCount.ValueInt32Pointer()
+	}
+	if known(plan.MaxSubscriptionCount) && (prior == nil || !plan.MaxSubscriptionCount.Equal(current.MaxSubscriptionCount)) {
+		settings.MaxSubscriptionCount = plan.MaxSubscriptionCount.ValueInt32Pointer()
+	}
+	if known(plan.MaxTransactedSessionCount) && (prior == nil || !plan.MaxTransactedSessionCount.Equal(current.MaxTransactedSessionCount)) {
+		settings.MaxTransactedSessionCount = plan.MaxTransactedSessionCount.ValueInt32Pointer()
+	}
+	if known(plan.MaxTransactionCount) && (prior == nil || !plan.MaxTransactionCount.Equal(current.MaxTransactionCount)) {
+		settings.MaxTransactionCount = plan.MaxTransactionCount.ValueInt32Pointer()
+	}
+	if known(plan.QueueControl1MaxDepth) && (prior == nil || !plan.QueueControl1MaxDepth.Equal(current.QueueControl1MaxDepth)) {
+		settings.QueueControl1MaxDepth = plan.QueueControl1MaxDepth.ValueInt32Pointer()
+	}
+	if known(plan.QueueControl1MinMsgBurst) && (prior == nil || !plan.QueueControl1MinMsgBurst.Equal(current.QueueControl1MinMsgBurst)) {
+		settings.QueueControl1MinMsgBurst = plan.QueueControl1MinMsgBurst.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect1MaxDepth) && (prior == nil || !plan.QueueDirect1MaxDepth.Equal(current.QueueDirect1MaxDepth)) {
+		settings.QueueDirect1MaxDepth = plan.QueueDirect1MaxDepth.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect1MinMsgBurst) && (prior == nil || !plan.QueueDirect1MinMsgBurst.Equal(current.QueueDirect1MinMsgBurst)) {
+		settings.QueueDirect1MinMsgBurst = plan.QueueDirect1MinMsgBurst.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect2MaxDepth) && (prior == nil || !plan.QueueDirect2MaxDepth.Equal(current.QueueDirect2MaxDepth)) {
+		settings.QueueDirect2MaxDepth = plan.QueueDirect2MaxDepth.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect2MinMsgBurst) && (prior == nil || !plan.QueueDirect2MinMsgBurst.Equal(current.QueueDirect2MinMsgBurst)) {
+		settings.QueueDirect2MinMsgBurst = plan.QueueDirect2MinMsgBurst.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect3MaxDepth) && (prior == nil || !plan.QueueDirect3MaxDepth.Equal(current.QueueDirect3MaxDepth)) {
+		settings.QueueDirect3MaxDepth = plan.QueueDirect3MaxDepth.ValueInt32Pointer()
+	}
+	if known(plan.QueueDirect3MinMsgBurst) && (prior == nil || !plan.QueueDirect3MinMsgBurst.Equal(current.QueueDirect3MinMsgBurst)) {
+		settings.QueueDirect3MinMsgBurst = plan.QueueDirect3MinMsgBurst.ValueInt32Pointer()
+	}
+	if known(plan.QueueGuaranteed1MaxDepth) && (prior == nil || !plan.QueueGuaranteed1MaxDepth.Equal(current.QueueGuaranteed1MaxDepth)) {
+		settings.QueueGuaranteed1MaxDepth = plan.QueueGuaranteed1MaxDepth.ValueInt32Pointer()
+	}
+	if known(plan.QueueGuaranteed1MinMsgBurst) && (prior == nil || !plan.QueueGuaranteed1MinMsgBurst.Equal(current.QueueGuaranteed1MinMsgBurst)) {
+		settings.QueueGuaranteed1MinMsgBurst = plan.QueueGuaranteed1MinMsgBurst.ValueInt32Pointer()
+	}
+	if known(plan.RejectMsgToSenderOnNoSubscriptionMatchEnabled) && (prior == nil || !plan.RejectMsgToSenderOnNoSubscriptionMatchEnabled.Equal(current.RejectMsgToSenderOnNoSubscriptionMatchEnabled)) {
+		settings.RejectMsgToSenderOnNoSubscriptionMatchEnabled = plan.RejectMsgToSenderOnNoSubscriptionMatchEnabled.ValueBoolPointer()
+	}
+	if known(plan.ReplicationAllowClientConnectWhenStandbyEnabled) && (prior == nil || !plan.ReplicationAllowClientConnectWhenStandbyEnabled.Equal(current.ReplicationAllowClientConnectWhenStandbyEnabled)) {
+		settings.ReplicationAllowClientConnectWhenStandbyEnabled = plan.ReplicationAllowClientConnectWhenStandbyEnabled.ValueBoolPointer()
+	}
+	if known(plan.ServiceMinKeepaliveTimeout) && (prior == nil || !plan.ServiceMinKeepaliveTimeout.Equal(current.ServiceMinKeepaliveTimeout)) {
+		settings.ServiceMinKeepaliveTimeout = plan.ServiceMinKeepaliveTimeout.ValueInt32Pointer()
+	}
+	if known(plan.ServiceSmfMaxConnectionCountPerClientUsername) && (prior == nil || !plan.ServiceSmfMaxConnectionCountPerClientUsername.Equal(current.ServiceSmfMaxConnectionCountPerClientUsername)) {
+		settings.ServiceSmfMaxConnectionCountPerClientUsername = plan.ServiceSmfMaxConnectionCountPerClientUsername.ValueInt32Pointer()
+	}
+	if known(plan.ServiceSmfMinKeepaliveEnabled) && (prior == nil || !plan.ServiceSmfMinKeepaliveEnabled.Equal(current.ServiceSmfMinKeepaliveEnabled)) {
+		settings.ServiceSmfMinKeepaliveEnabled = plan.ServiceSmfMinKeepaliveEnabled.ValueBoolPointer()
+	}
+	if known(plan.ServiceWebInactiveTimeout) && (prior == nil || !plan.ServiceWebInactiveTimeout.Equal(current.ServiceWebInactiveTimeout)) {
+		settings.ServiceWebInactiveTimeout = plan.ServiceWebInactiveTimeout.ValueInt32Pointer()
+	}
+	if known(plan.ServiceWebMaxConnectionCountPerClientUsername) && (prior == nil || !plan.ServiceWebMaxConnectionCountPerClientUsername.Equal(current.ServiceWebMaxConnectionCountPerClientUsername)) {
+		settings.ServiceWebMaxConnectionCountPerClientUsername = plan.ServiceWebMaxConnectionCountPerClientUsername.ValueInt32Pointer()
+	}
+	if known(plan.ServiceWebMaxPayload) && (prior == nil || !plan.ServiceWebMaxPayload.Equal(current.ServiceWebMaxPayload)) {
+		settings.ServiceWebMaxPayload = plan.ServiceWebMaxPayload.ValueInt32Pointer()
+	}
+	if known(plan.TcpCongestionWindowSize) && (prior == nil || !plan.TcpCongestionWindowSize.Equal(current.TcpCongestionWindowSize)) {
+		settings.TcpCongestionWindowSize = plan.TcpCongestionWindowSize.ValueInt32Pointer()
+	}
+	if known(plan.TcpKeepaliveCount) && (prior == nil || !plan.TcpKeepaliveCount.Equal(current.TcpKeepaliveCount)) {
+		settings.TcpKeepaliveCount = plan.TcpKeepaliveCount.ValueInt32Pointer()
+	}
+	if known(plan.TcpKeepaliveIdleTime) && (prior == nil || !plan.TcpKeepaliveIdleTime.Equal(current.TcpKeepaliveIdleTime)) {
+		settings.TcpKeepaliveIdleTime = plan.TcpKeepaliveIdleTime.ValueInt32Pointer()
+	}
+	if known(plan.TcpKeepaliveInterval) && (prior == nil || !plan.TcpKeepaliveInterval.Equal(current.TcpKeepaliveInterval)) {
+		settings.TcpKeepaliveInterval = plan.TcpKeepaliveInterval.ValueInt32Pointer()
+	}
+	if known(plan.TcpMaxSegmentSize) && (prior == nil || !plan.TcpMaxSegmentSize.Equal(current.TcpMaxSegmentSize)) {
+		settings.TcpMaxSegmentSize = plan.TcpMaxSegmentSize.ValueInt32Pointer()
+	}
+	if known(plan.TcpMaxWindowSize) && (prior == nil || !plan.TcpMaxWindowSize.Equal(current.TcpMaxWindowSize)) {
+		settings.TcpMaxWindowSize = plan.TcpMaxWindowSize.ValueInt32Pointer()
+	}
+	if known(plan.TlsAllowDowngradeToPlainTextEnabled) && (prior == nil || !plan.TlsAllowDowngradeToPlainTextEnabled.Equal(current.TlsAllowDowngradeToPlainTextEnabled)) {
+		settings.TlsAllowDowngradeToPlainTextEnabled = plan.TlsAllowDowngradeToPlainTextEnabled.ValueBoolPointer()
+	}
+	// the spool usage thresholds are nested
+	threshold := missioncontrol.ProvisionedEndpointSpoolUsageAlertThresholds{}
+	if known(plan.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent) &&
+		(prior == nil || !plan.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent.Equal(current.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent)) {
+		threshold.ClearPercent = plan.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent.ValueInt32Pointer()
+	}
+	if known(plan.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent) &&
+		(prior == nil || !plan.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent.Equal(current.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent)) {
+		threshold.SetPercent = plan.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent.ValueInt32Pointer()
+	}
+	if threshold != (missioncontrol.ProvisionedEndpointSpoolUsageAlertThresholds{}) {
+		settings.EventClientProvisionedEndpointSpoolUsageThreshold = &threshold
+	}
+	return settings
+}
+
+// helper converting the settings of a client profile to the model, missing settings become null
+func clientProfileToModel(profile missioncontrol.ClientProfile, model *clientProfileSettingsModel) {
+	model.AllowBridgeConnectionsEnabled = types.BoolPointerValue(profile.AllowBridgeConnectionsEnabled)
+	model.AllowGuaranteedEndpointCreateEnabled = types.BoolPointerValue(profile.AllowGuaranteedEndpointCreateEnabled)
+	model.AllowGuaranteedMsgReceiveEnabled = types.BoolPointerValue(profile.AllowGuaranteedMsgReceiveEnabled)
+	model.AllowGuaranteedMsgSendEnabled = types.BoolPointerValue(profile.AllowGuaranteedMsgSendEnabled)
+	model.AllowSharedSubscriptionsEnabled = types.BoolPointerValue(profile.AllowSharedSubscriptionsEnabled)
+	model.AllowTransactedSessionsEnabled = types.BoolPointerValue(profile.AllowTransactedSessionsEnabled)
+	model.ApiQueueManagementCopyFromOnCreateTemplateName = types.StringPointerValue(profile.ApiQueueManagementCopyFromOnCreateTemplateName)
+	model.ApiTopicEndpointManagementCopyFromOnCreateTemplateName = types.StringPointerValue(profile.ApiTopicEndpointManagementCopyFromOnCreateTemplateName)
+	model.CompressionEnabled = types.BoolPointerValue(profile.CompressionEnabled)
+	model.ElidingDelay = types.Int32PointerValue(profile.ElidingDelay)
+	model.ElidingEnabled = types.BoolPointerValue(profile.ElidingEnabled)
+	model.ElidingMaxTopicCount = types.Int32PointerValue(profile.ElidingMaxTopicCount)
+	model.MaxConnectionCountPerClientUsername = types.Int32PointerValue(profile.MaxConnectionCountPerClientUsername)
+	model.MaxEgressFlowCount = types.Int32PointerValue(profile.MaxEgressFlowCount)
+	model.MaxEndpointCountPerClientUsername = types.Int32PointerValue(profile.MaxEndpointCountPerClientUsername)
+	model.MaxIngressFlowCount = types.Int32PointerValue(profile.MaxIngressFlowCount)
+	model.MaxSubscriptionCount = types.Int32PointerValue(profile.MaxSubscriptionCount)
+	model.MaxTransactedSessionCount = types.Int32PointerValue(profile.MaxTransactedSessionCount)
+	model.MaxTransactionCount = types.Int32PointerValue(profile.MaxTransactionCount)
+	model.QueueControl1MaxDepth = types.Int32PointerValue(profile.QueueControl1MaxDepth)
+	model.QueueControl1MinMsgBurst = types.Int32PointerValue(profile.QueueControl1MinMsgBurst)
+	model.QueueDirect1MaxDepth = types.Int32PointerValue(profile.QueueDirect1MaxDepth)
+	model.QueueDirect1MinMsgBurst = types.Int32PointerValue(profile.QueueDirect1MinMsgBurst)
+	model.QueueDirect2MaxDepth = types.Int32PointerValue(profile.QueueDirect2MaxDepth)
+	model.QueueDirect2MinMsgBurst = types.Int32PointerValue(profile.QueueDirect2MinMsgBurst)
+	model.QueueDirect3MaxDepth = types.Int32PointerValue(profile.QueueDirect3MaxDepth)
+	model.QueueDirect3MinMsgBurst = types.Int32PointerValue(profile.QueueDirect3MinMsgBurst)
+	model.QueueGuaranteed1MaxDepth = types.Int32PointerValue(profile.QueueGuaranteed1MaxDepth)
+	model.QueueGuaranteed1MinMsgBurst = types.Int32PointerValue(profile.QueueGuaranteed1MinMsgBurst)
+	model.RejectMsgToSenderOnNoSubscriptionMatchEnabled = types.BoolPointerValue(profile.RejectMsgToSenderOnNoSubscriptionMatchEnabled)
+	model.ReplicationAllowClientConnectWhenStandbyEnabled = types.BoolPointerValue(profile.ReplicationAllowClientConnectWhenStandbyEnabled)
+	model.ServiceMinKeepaliveTimeout = types.Int32PointerValue(profile.ServiceMinKeepaliveTimeout)
+	model.ServiceSmfMaxConnectionCountPerClientUsername = types.Int32PointerValue(profile.ServiceSmfMaxConnectionCountPerClientUsername)
+	model.ServiceSmfMinKeepaliveEnabled = types.BoolPointerValue(profile.ServiceSmfMinKeepaliveEnabled)
+	model.ServiceWebInactiveTimeout = types.Int32PointerValue(profile.ServiceWebInactiveTimeout)
+	model.ServiceWebMaxConnectionCountPerClientUsername = types.Int32PointerValue(profile.ServiceWebMaxConnectionCountPerClientUsername)
+	model.ServiceWebMaxPayload = types.Int32PointerValue(profile.ServiceWebMaxPayload)
+	model.TcpCongestionWindowSize = types.Int32PointerValue(profile.TcpCongestionWindowSize)
+	model.TcpKeepaliveCount = types.Int32PointerValue(profile.TcpKeepaliveCount)
+	model.TcpKeepaliveIdleTime = types.Int32PointerValue(profile.TcpKeepaliveIdleTime)
+	model.TcpKeepaliveInterval = types.Int32PointerValue(profile.TcpKeepaliveInterval)
+	model.TcpMaxSegmentSize = types.Int32PointerValue(profile.TcpMaxSegmentSize)
+	model.TcpMaxWindowSize = types.Int32PointerValue(profile.TcpMaxWindowSize)
+	model.TlsAllowDowngradeToPlainTextEnabled = types.BoolPointerValue(profile.TlsAllowDowngradeToPlainTextEnabled)
+	model.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent = types.Int32Null()
+	model.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent = types.Int32Null()
+	if threshold := profile.EventClientProvisionedEndpointSpoolUsageThreshold; threshold != nil {
+		model.EventClientProvisionedEndpointSpoolUsageThresholdClearPercent = types.Int32PointerValue(threshold.ClearPercent)
+		model.EventClientProvisionedEndpointSpoolUsageThresholdSetPercent = types.Int32PointerValue(threshold.SetPercent)
+	}
+}
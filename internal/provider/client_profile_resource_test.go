@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccClientProfileResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// out of range values are rejected
+			{
+				Config:      testClientProfileConfig("cp1", "ocs-prov-cp1", 7, true),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
+			},
+			// Create and Read testing, settings not configured are taken from the broker
+			{
+				Config: testClientProfileConfig("cp1", "ocs-prov-cp1", 3, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_profile.cp1",
+						tfjsonpath.New("tcp_keepalive_count"),
+						knownvalue.Int32Exact(3),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_profile.cp1",
+						tfjsonpath.New("max_subscription_count"),
+						knownvalue.Int32Exact(5000),
+					),
+				},
+			},
+			// the defaults of the broker do not cause diffs
+			{
+				Config: testClientProfileConfig("cp1", "ocs-prov-cp1", 3, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "gsolaceclustermgr_client_profile.cp1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testClientProfileImportId("gsolaceclustermgr_client_profile.cp1"),
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testClientProfileConfig("cp1", "ocs-prov-cp1", 4, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_client_profile.cp1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_profile.cp1",
+						tfjsonpath.New("tcp_keepalive_count"),
+						knownvalue.Int32Exact(4),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_profile.cp1",
+						tfjsonpath.New("allow_guaranteed_msg_send_enabled"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testClientProfileImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return rs.Primary.Attributes["service_id"] + "/" + rs.Primary.Attributes["name"], nil
+	}
+}
+
+func testClientProfileConfig(rname string, brokerName string, keepaliveCount int, guaranteed bool) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + brokerName + `"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_client_profile" "` + rname + `" {
+		service_id                           = gsolaceclustermgr_broker.` + rname + `.id
+		name                                 = "guaranteed"
+		allow_guaranteed_msg_send_enabled    = ` + fmt.Sprint(guaranteed) + `
+		allow_guaranteed_msg_receive_enabled = ` + fmt.Sprint(guaranteed) + `
+		tcp_keepalive_count                  = ` + fmt.Sprint(keepaliveCount) + `
+	}
+	`
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clientProfilesPageSize is the number of client profiles requested per page
+const clientProfilesPageSize = 100
+
+type clientProfilesDataSourceModel struct {
+	ServiceId      types.String         `tfsdk:"service_id"`
+	ClientProfiles []clientProfileModel `tfsdk:"client_profiles"`
+}
+
+// clientProfileModel maps a client profile of the data source.
+type clientProfileModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	clientProfileSettingsModel
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clientProfilesDataSource{}
+	_ datasource.DataSourceWithConfigure = &clientProfilesDataSource{}
+)
+
+// NewClientProfilesDataSource is a helper function to simplify the provider implementation.
+func NewClientProfilesDataSource() datasource.DataSource {
+	return &clientProfilesDataSource{}
+}
+
+// clientProfilesDataSource is the data source implementation.
+type clientProfilesDataSource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the data source type name.
+func (d *clientProfilesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_profiles"
+}
+
+// Schema defines the schema for the data source.
+func (d *clientProfilesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	profileAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+	}
+	// the settings are the ones of the client profile resource, read-only
+	for name, a := range clientProfileSettingsAttributes() {
+		switch a := a.(type) {
+		case resourceschema.BoolAttribute:
+			profileAttributes[name] = schema.BoolAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case resourceschema.Int32Attribute:
+			profileAttributes[name] = schema.Int32Attribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case resourceschema.StringAttribute:
+			profileAttributes[name] = schema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		default:
+			// a programming error, fail loudly instead of silently dropping the setting
+			panic(fmt.Sprintf("client profile setting %s has unsupported attribute type %T", name, a))
+		}
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "All client profiles of a broker service, including the ones created by default",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+			},
+			"client_profiles": schema.ListNestedAttribute{
+				MarkdownDescription: "The client profiles with all their settings",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: profileAttributes,
+				},
+			},
+		},
+	}
+}
+
+// Read resource information.
+func (d *clientProfilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var currentState clientProfilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	serviceId := currentState.ServiceId.ValueString()
+
+	// the list only contains a summary of each profile, so the profiles are fetched one by one
+	var names []string
+	for pageNumber := 1; ; pageNumber++ {
+		pageSize := clientProfilesPageSize
+		params := missioncontrol.GetClientProfilesParams{
+			PageNumber: &pageNumber,
+			PageSize:   &pageSize,
+		}
+		listResp, err := d.cMProviderData.Client.GetClientProfilesWithResponse(ctx, serviceId, &params, d.cMProviderData.BearerReqEditorFn)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting client profiles",
+				"Could not get client profiles, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(listResp.Body)))
+		if listResp.StatusCode() != 200 {
+			resp.Diagnostics.AddError(
+				"Error getting client profiles",
+				fmt.Sprintf("Unexpected response code: %v\n%s", listResp.StatusCode(), parseErrorResponse(listResp.Body)),
+			)
+			return
+		}
+		for _, summary := range listResp.JSON200.Data {
+			names = append(names, summary.Name)
+		}
+		if !hasNextPage(listResp.JSON200.Meta, len(listResp.JSON200.Data), pageSize) {
+			break
+		}
+	}
+
+	profiles := []clientProfileModel{}
+	for _, name := range names {
+		getResp, err := d.cMProviderData.Client.GetClientProfileWithResponse(ctx, serviceId, name, d.cMProviderData.BearerReqEditorFn)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting client profile",
+				"Could not get client profile, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+		if getResp.StatusCode() == 404 {
+			// deleted while listing
+			continue
+		}
+		if getResp.StatusCode() != 200 {
+			resp.Diagnostics.AddError(
+				"Error getting client profile",
+				fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+			)
+			return
+		}
+		profile := getResp.JSON200.Data
+		model := clientProfileModel{
+			ID:   types.StringPointerValue(profile.Id),
+			Name: types.StringValue(profile.Name),
+		}
+		clientProfileToModel(profile, &model.clientProfileSettingsModel)
+		profiles = append(profiles, model)
+	}
+
+	currentState.ClientProfiles = profiles
+	tflog.Debug(ctx, fmt.Sprintf("Read %d client profiles of broker %s", len(profiles), serviceId))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *clientProfilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "configure client profiles datasource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cMProviderData = cMProviderData
+}
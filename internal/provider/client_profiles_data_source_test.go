@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccClientProfilesDataSource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// the default profile and the managed one are listed with all settings
+			{
+				Config: testClientProfileConfig("cps1", "ocs-prov-cps1", 3, true) + `
+				data "gsolaceclustermgr_client_profiles" "cps1" {
+					service_id = gsolaceclustermgr_client_profile.cps1.service_id
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_client_profiles.cps1",
+						tfjsonpath.New("client_profiles"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_client_profiles.cps1",
+						tfjsonpath.New("client_profiles").AtSliceIndex(0).AtMapKey("name"),
+						knownvalue.StringExact("default"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_client_profiles.cps1",
+						tfjsonpath.New("client_profiles").AtSliceIndex(1).AtMapKey("tcp_keepalive_count"),
+						knownvalue.Int32Exact(3),
+					),
+				},
+			},
+		},
+	})
+}
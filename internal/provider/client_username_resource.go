@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clientUsernameResourceModel maps the resource schema data.
+type clientUsernameResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ServiceId         types.String `tfsdk:"service_id"`
+	MsgVpnName        types.String `tfsdk:"msg_vpn_name"`
+	ClientUsername    types.String `tfsdk:"client_username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWo        types.String `tfsdk:"password_wo"`
+	PasswordWoVersion types.Int64  `tfsdk:"password_wo_version"`
+	AclProfileName    types.String `tfsdk:"acl_profile_name"`
+	ClientProfileName types.String `tfsdk:"client_profile_name"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+}
+
+// sempClientUsername is the SEMPv2 representation of a client username. The password is never returned by the broker
+type sempClientUsername struct {
+	MsgVpnName        string  `json:"msgVpnName,omitempty"`
+	ClientUsername    string  `json:"clientUsername,omitempty"`
+	Password          *string `json:"password,omitempty"`
+	AclProfileName    *string `json:"aclProfileName,omitempty"`
+	ClientProfileName *string `json:"clientProfileName,omitempty"`
+	Enabled           *bool   `json:"enabled,omitempty"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clientUsernameResource{}
+	_ resource.ResourceWithConfigure   = &clientUsernameResource{}
+	_ resource.ResourceWithImportState = &clientUsernameResource{}
+)
+
+// NewClientUsernameResource is a helper function to simplify the provider implementation.
+func NewClientUsernameResource() resource.Resource {
+	return &clientUsernameResource{}
+}
+
+// clientUsernameResource is the resource implementation.
+type clientUsernameResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *clientUsernameResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_username"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clientUsernameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure client username resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *clientUsernameResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Client username of a broker service, managed through the mission control SEMP proxy. Settings which are not configured keep the value of the broker. Import using *service_id/msg_vpn_name/client_username*",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"msg_vpn_name": schema.StringAttribute{
+				MarkdownDescription: "The message VPN of the broker service, see *msg_vpn_name* of the broker",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_username": schema.StringAttribute{
+				MarkdownDescription: "The name of the client username",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 189),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of the client username, kept in the state as sensitive value. Prefer *password_wo*, use this only with terraform versions before 1.11. " +
+					"The broker never returns it, so changes made outside of terraform are not detected. Not imported",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(128),
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The write-only password of the client username, it is never stored in the state and needs terraform 1.11 or newer. " +
+					"It is sent on creation and whenever *password_wo_version* changes",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(128),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this version to send a new *password_wo* to the broker",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"acl_profile_name": schema.StringAttribute{
+				MarkdownDescription: "The ACL profile of the client username",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_profile_name": schema.StringAttribute{
+				MarkdownDescription: "The client profile of the client username",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether clients can connect using the client username",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "service_id/msg_vpn_name/client_username",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *clientUsernameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState clientUsernameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	// write-only values are only part of the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &plannedState.PasswordWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := clientUsernameSettings(plannedState, nil)
+	body.MsgVpnName = plannedState.MsgVpnName.ValueString()
+	body.ClientUsername = plannedState.ClientUsername.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Creating client username %s", body.ClientUsername))
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPost, plannedState.ServiceId.ValueString(), "msgVpns/"+url.PathEscape(body.MsgVpnName)+"/clientUsernames", body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating client username",
+			"Could not create client username, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error creating client username",
+			sempResp.errorMessage(),
+		)
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error creating client username",
+			fmt.Sprintf("Client username %s vanished", plannedState.ClientUsername.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plannedState.PasswordWo = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *clientUsernameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState clientUsernameResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clientUsernameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState clientUsernameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &plannedState.PasswordWo)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only send what has changed
+	body := clientUsernameSettings(plannedState, &currentState)
+	if body != (sempClientUsername{}) {
+		tflog.Info(ctx, fmt.Sprintf("Updating client username %s", plannedState.ClientUsername.ValueString()))
+		sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPatch, plannedState.ServiceId.ValueString(), clientUsernamePath(plannedState), body, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating client username",
+				"Could not update client username, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !sempResp.ok() {
+			resp.Diagnostics.AddError(
+				"Error updating client username",
+				sempResp.errorMessage(),
+			)
+			return
+		}
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error updating client username",
+			fmt.Sprintf("Client username %s vanished", plannedState.ClientUsername.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plannedState.PasswordWo = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *clientUsernameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState clientUsernameResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodDelete, currentState.ServiceId.ValueString(), clientUsernamePath(currentState), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting client username",
+			"Could not delete client username, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if sempResp.notFound() {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find client username %s", currentState.ClientUsername.ValueString()))
+		// this is tolerable!
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error deleting client username",
+			sempResp.errorMessage(),
+		)
+	}
+}
+
+// ImportState imports a client username using service_id/msg_vpn_name/client_username, the passwords stay empty.
+func (r *clientUsernameResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.SplitN(req.ID, "/", 3)
+	if len(ids) != 3 || slices.Contains(ids, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/msg_vpn_name/client_username. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("msg_vpn_name"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("client_username"), ids[2])...)
+}
+
+// helper reading the client username into the model, returns false if it does not exist.
+// The password is left as is, the broker does not return it
+func (r *clientUsernameResource) get(ctx context.Context, model *clientUsernameResourceModel, diagnostics *diag.Diagnostics) bool {
+	serviceId := model.ServiceId.ValueString()
+	var clientUsername sempClientUsername
+	found := sempGetObject(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, clientUsernamePath(*model), &clientUsername, "Error getting client username", diagnostics)
+	if diagnostics.HasError() || !found {
+		return false
+	}
+
+	model.ID = types.StringValue(serviceId + "/" + model.MsgVpnName.ValueString() + "/" + model.ClientUsername.ValueString())
+	model.AclProfileName = types.StringPointerValue(clientUsername.AclProfileName)
+	model.ClientProfileName = types.StringPointerValue(clientUsername.ClientProfileName)
+	model.Enabled = types.BoolPointerValue(clientUsername.Enabled)
+	return true
+}
+
+// helper returning the SEMP path of the client username
+func clientUsernamePath(model clientUsernameResourceModel) string {
+	return "msgVpns/" + url.PathEscape(model.MsgVpnName.ValueString()) + "/clientUsernames/" + url.PathEscape(model.ClientUsername.ValueString())
+}
+
+// helper collecting the known settings of the plan. If a prior state is given, only the changed settings are collected
+func clientUsernameSettings(plan clientUsernameResourceModel, prior *clientUsernameResourceModel) sempClientUsername {
+	var settings sempClientUsername
+	var current clientUsernameResourceModel
+	if prior != nil {
+		current = *prior
+	}
+	// the write-only password is not in the state, it is sent on creation, when its version changes
+	// or when it replaces the password. A removed password is cleared on the broker
+	switch {
+	case known(plan.PasswordWo) && (prior == nil || !plan.PasswordWoVersion.Equal(current.PasswordWoVersion) || !plan.Password.Equal(current.Password)):
+		settings.Password = plan.PasswordWo.ValueStringPointer()
+	case prior == nil && known(plan.Password):
+		settings.Password = plan.Password.ValueStringPointer()
+	case prior != nil && !plan.Password.Equal(current.Password):
+		password := plan.Password.ValueString()
+		settings.Password = &password
+	}
+	// unknown values are not configured and taken from the broker
+	if known(plan.AclProfileName) && (prior == nil || !plan.AclProfileName.Equal(current.AclProfileName)) {
+		settings.AclProfileName = plan.AclProfileName.ValueStringPointer()
+	}
+	if known(plan.ClientProfileName) && (prior == nil || !plan.ClientProfileName.Equal(current.ClientProfileName)) {
+		settings.ClientProfileName = plan.ClientProfileName.ValueStringPointer()
+	}
+	if known(plan.Enabled) && (prior == nil || !plan.Enabled.Equal(current.Enabled)) {
+		settings.Enabled = plan.Enabled.ValueBoolPointer()
+	}
+	return settings
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccClientUsernameResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// Create and Read testing, bound to the ACL profile and the default client profile
+			{
+				Config: testClientUsernameConfig("u1", "ocs-prov-u1", "secret1", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_username.u1",
+						tfjsonpath.New("acl_profile_name"),
+						knownvalue.StringExact("orders-app"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_username.u1",
+						tfjsonpath.New("client_profile_name"),
+						knownvalue.StringExact("default"),
+					),
+				},
+			},
+			// the password is not read back, which does not cause diffs
+			{
+				Config: testClientUsernameConfig("u1", "ocs-prov-u1", "secret1", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing, the password can't be imported
+			{
+				ResourceName:            "gsolaceclustermgr_client_username.u1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "password_wo_version"},
+			},
+			// Update in place, password rotation
+			{
+				Config: testClientUsernameConfig("u1", "ocs-prov-u1", "secret2", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_client_username.u1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_username.u1",
+						tfjsonpath.New("enabled"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccClientUsernameResourceWriteOnlyPassword(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		// write-only attributes need terraform 1.11
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// the write-only password is not kept in the state
+			{
+				Config: testClientUsernameConfigWriteOnly("u2", "ocs-prov-u2", "secret1", 1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_username.u2",
+						tfjsonpath.New("password_wo"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_client_username.u2",
+						tfjsonpath.New("password_wo_version"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+			// a changed password alone does not cause diffs
+			{
+				Config: testClientUsernameConfigWriteOnly("u2", "ocs-prov-u2", "secret2", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// password rotation by a new version
+			{
+				Config: testClientUsernameConfigWriteOnly("u2", "ocs-prov-u2", "secret2", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_client_username.u2", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestClientUsernameSettingsPassword(t *testing.T) {
+	model := func(password string, passwordWo string, version int64) clientUsernameResourceModel {
+		m := clientUsernameResourceModel{Password: types.StringNull(), PasswordWo: types.StringNull(), PasswordWoVersion: types.Int64Null()}
+		if password != "" {
+			m.Password = types.StringValue(password)
+		}
+		if passwordWo != "" {
+			m.PasswordWo = types.StringValue(passwordWo)
+		}
+		if version != 0 {
+			m.PasswordWoVersion = types.Int64Value(version)
+		}
+		return m
+	}
+	prior := func(password string, passwordWo string, version int64) *clientUsernameResourceModel {
+		m := model(password, passwordWo, version)
+		return &m
+	}
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name  string
+		plan  clientUsernameResourceModel
+		prior *clientUsernameResourceModel
+		want  *string
+	}{
+		{"create without password", model("", "", 0), nil, nil},
+		{"create with password", model("p1", "", 0), nil, str("p1")},
+		{"create with write-only password", model("", "w1", 1), nil, str("w1")},
+		{"unchanged password", model("p1", "", 0), prior("p1", "", 0), nil},
+		{"changed password", model("p2", "", 0), prior("p1", "", 0), str("p2")},
+		{"removed password", model("", "", 0), prior("p1", "", 0), str("")},
+		{"unchanged version", model("", "w2", 1), prior("", "", 1), nil},
+		{"changed version", model("", "w2", 2), prior("", "", 1), str("w2")},
+		{"password replaced by write-only password", model("", "w1", 0), prior("p1", "", 0), str("w1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clientUsernameSettings(tt.plan, tt.prior).Password)
+		})
+	}
+}
+
+func testClientUsernameConfig(rname string, brokerName string, password string, enabled bool) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_acl_profile" "%[1]s" {
+		service_id       = gsolaceclustermgr_broker.%[1]s.id
+		msg_vpn_name     = gsolaceclustermgr_broker.%[1]s.msg_vpn_name
+		acl_profile_name = "orders-app"
+	}
+	resource "gsolaceclustermgr_client_username" "%[1]s" {
+		service_id       = gsolaceclustermgr_broker.%[1]s.id
+		msg_vpn_name     = gsolaceclustermgr_broker.%[1]s.msg_vpn_name
+		client_username  = "orders-app"
+		password         = "%[3]s"
+		acl_profile_name = gsolaceclustermgr_acl_profile.%[1]s.acl_profile_name
+		enabled          = %[4]t
+	}
+	`, rname, brokerName, password, enabled)
+}
+
+func testClientUsernameConfigWriteOnly(rname string, brokerName string, password string, version int) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_client_username" "%[1]s" {
+		service_id          = gsolaceclustermgr_broker.%[1]s.id
+		msg_vpn_name        = gsolaceclustermgr_broker.%[1]s.msg_vpn_name
+		client_username     = "orders-app"
+		password_wo         = "%[3]s"
+		password_wo_version = %[4]d
+	}
+	`, rname, brokerName, password, version)
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// connectionEndpointDnsNameResourceModel maps the resource schema data.
+type connectionEndpointDnsNameResourceModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	ServiceId            types.String   `tfsdk:"service_id"`
+	ConnectionEndpointId types.String   `tfsdk:"connection_endpoint_id"`
+	DnsName              types.String   `tfsdk:"dns_name"`
+	DnsRecordType        types.String   `tfsdk:"dns_record_type"`
+	DomainType           types.String   `tfsdk:"domain_type"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &connectionEndpointDnsNameResource{}
+	_ resource.ResourceWithConfigure   = &connectionEndpointDnsNameResource{}
+	_ resource.ResourceWithImportState = &connectionEndpointDnsNameResource{}
+)
+
+// NewConnectionEndpointDnsNameResource is a helper function to simplify the provider implementation.
+func NewConnectionEndpointDnsNameResource() resource.Resource {
+	return &connectionEndpointDnsNameResource{}
+}
+
+// connectionEndpointDnsNameResource is the resource implementation.
+type connectionEndpointDnsNameResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *connectionEndpointDnsNameResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_endpoint_dns_name"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *connectionEndpointDnsNameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure connection endpoint dns name resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *connectionEndpointDnsNameResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom DNS name of a service connection endpoint. Changing *service_id* or *connection_endpoint_id* moves the DNS name to the new endpoint, " +
+			"so it stays resolvable during the switch (e.g. for blue/green cutovers). Import using *service_id/connection_endpoint_id/dns_name*",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+			},
+			"connection_endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the connection endpoint",
+				Required:            true,
+			},
+			"dns_name": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified domain name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(230),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`),
+						"must contain only lowercase letters, digits, hyphens or dots and cannot end with a hyphen or dot",
+					),
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the DNS name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_record_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *connectionEndpointDnsNameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState connectionEndpointDnsNameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	endpointId := plannedState.ConnectionEndpointId.ValueString()
+	body := missioncontrol.CreateConnectionEndpointDnsNameJSONRequestBody{
+		DnsName: plannedState.DnsName.ValueString(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("Creating DNS name %s on connection endpoint %s", body.DnsName, endpointId))
+
+	createResp, err := r.cMProviderData.Client.CreateConnectionEndpointDnsNameWithResponse(ctx, serviceId, endpointId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating DNS name",
+			"Could not create DNS name, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(createResp.Body)))
+	if createResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error creating DNS name",
+			fmt.Sprintf("Unexpected response code: %v\n%s", createResp.StatusCode(), parseErrorResponse(createResp.Body)),
+		)
+		return
+	}
+	// store the keys right away, so a failed or timed out creation leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), plannedState.ServiceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_endpoint_id"), plannedState.ConnectionEndpointId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dns_name"), plannedState.DnsName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(createResp.JSON202.Data.Id), createTimeout, "Error creating DNS name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.mustGet(ctx, &plannedState, "Error creating DNS name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *connectionEndpointDnsNameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState connectionEndpointDnsNameResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update moves the DNS name to another connection endpoint.
+func (r *connectionEndpointDnsNameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState connectionEndpointDnsNameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plannedState.ServiceId.Equal(currentState.ServiceId) || !plannedState.ConnectionEndpointId.Equal(currentState.ConnectionEndpointId) {
+		serviceId := currentState.ServiceId.ValueString()
+		endpointId := currentState.ConnectionEndpointId.ValueString()
+		dnsName := currentState.DnsName.ValueString()
+		body := missioncontrol.MoveConnectionEndpointDnsNameJSONRequestBody{
+			TargetServiceId:            plannedState.ServiceId.ValueStringPointer(),
+			TargetConnectionEndpointId: plannedState.ConnectionEndpointId.ValueStringPointer(),
+		}
+		tflog.Info(ctx, fmt.Sprintf("Moving DNS name %s from connection endpoint %s to %s", dnsName, endpointId, *body.TargetConnectionEndpointId))
+
+		moveResp, err := r.cMProviderData.Client.MoveConnectionEndpointDnsNameWithResponse(ctx, serviceId, endpointId, dnsName, body, r.cMProviderData.BearerReqEditorFn)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error moving DNS name",
+				"Could not move DNS name, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(moveResp.Body)))
+		if moveResp.StatusCode() != 202 {
+			resp.Diagnostics.AddError(
+				"Error moving DNS name",
+				fmt.Sprintf("Unexpected response code: %v\n%s", moveResp.StatusCode(), parseErrorResponse(moveResp.Body)),
+			)
+			return
+		}
+		waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(moveResp.JSON202.Data.Id), updateTimeout, "Error moving DNS name", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.mustGet(ctx, &plannedState, "Error moving DNS name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *connectionEndpointDnsNameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState connectionEndpointDnsNameResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := currentState.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := currentState.ServiceId.ValueString()
+	endpointId := currentState.ConnectionEndpointId.ValueString()
+	dnsName := currentState.DnsName.ValueString()
+	delResp, err := r.cMProviderData.Client.DeleteConnectionEndpointDnsNameWithResponse(ctx, serviceId, endpointId, dnsName, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DNS name",
+			"Could not delete DNS name, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
+	if delResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find DNS name %s of connection endpoint %s", dnsName, endpointId))
+		// this is tolerable!
+		return
+	}
+	if delResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error deleting DNS name",
+			fmt.Sprintf("Unexpected response code: %v\n%s", delResp.StatusCode(), parseErrorResponse(delResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(delResp.JSON202.Data.Id), deleteTimeout, "Error deleting DNS name", &resp.Diagnostics)
+}
+
+// ImportState imports a DNS name using service_id/connection_endpoint_id/dns_name.
+func (r *connectionEndpointDnsNameResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/connection_endpoint_id/dns_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_endpoint_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dns_name"), parts[2])...)
+}
+
+// helper reading the DNS name into the model, an unknown DNS name is reported as error with the given summary
+func (r *connectionEndpointDnsNameResource) mustGet(ctx context.Context, model *connectionEndpointDnsNameResourceModel, summary string, diagnostics *diag.Diagnostics) {
+	found := r.get(ctx, model, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	if !found {
+		diagnostics.AddError(
+			summary,
+			fmt.Sprintf("Could not find DNS name %s on connection endpoint %s", model.DnsName.ValueString(), model.ConnectionEndpointId.ValueString()),
+		)
+	}
+}
+
+// helper reading the DNS name into the model, returns false if it (or its endpoint) does not exist
+func (r *connectionEndpointDnsNameResource) get(ctx context.Context, model *connectionEndpointDnsNameResourceModel, diagnostics *diag.Diagnostics) bool {
+	getResp, err := r.cMProviderData.Client.GetConnectionEndpointDnsNamesWithResponse(ctx, model.ServiceId.ValueString(), model.ConnectionEndpointId.ValueString(), r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting DNS names",
+			"Could not get DNS names, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return false
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting DNS names",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return false
+	}
+
+	for _, dnsName := range getResp.JSON200.Data {
+		if dnsName.DnsName != nil && *dnsName.DnsName == model.DnsName.ValueString() {
+			model.ID = types.StringPointerValue(dnsName.Id)
+			model.DnsRecordType = types.StringPointerValue(dnsName.DnsRecordType)
+			model.DomainType = types.StringPointerValue((*string)(dnsName.DomainType))
+			return true
+		}
+	}
+	return false
+}
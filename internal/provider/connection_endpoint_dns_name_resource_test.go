@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccConnectionEndpointDnsNameResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// invalid DNS names are rejected
+			{
+				Config:      testConnectionEndpointDnsNameConfig("dns1", "blue", "Broker.Example.com"),
+				ExpectError: regexp.MustCompile("must contain only lowercase letters"),
+			},
+			// Create and Read testing
+			{
+				Config: testConnectionEndpointDnsNameConfig("dns1", "blue", "broker.example.com"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_connection_endpoint_dns_name.dns1",
+						tfjsonpath.New("domain_type"),
+						knownvalue.StringExact("CustomerManaged"),
+					),
+					statecheck.CompareValuePairs(
+						"gsolaceclustermgr_connection_endpoint_dns_name.dns1",
+						tfjsonpath.New("connection_endpoint_id"),
+						"gsolaceclustermgr_connection_endpoint.blue",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "gsolaceclustermgr_connection_endpoint_dns_name.dns1",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testConnectionEndpointDnsNameImportId("gsolaceclustermgr_connection_endpoint_dns_name.dns1"),
+				ImportStateVerifyIdentifierAttribute: "dns_name",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+			// switching the endpoint moves the DNS name in place
+			{
+				Config: testConnectionEndpointDnsNameConfig("dns1", "green", "broker.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_connection_endpoint_dns_name.dns1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"gsolaceclustermgr_connection_endpoint_dns_name.dns1",
+						tfjsonpath.New("connection_endpoint_id"),
+						"gsolaceclustermgr_connection_endpoint.green",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testConnectionEndpointDnsNameImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return rs.Primary.Attributes["service_id"] + "/" + rs.Primary.Attributes["connection_endpoint_id"] + "/" + rs.Primary.Attributes["dns_name"], nil
+	}
+}
+
+func testConnectionEndpointDnsNameConfig(rname string, endpoint string, dnsName string) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "ocs-prov-` + rname + `"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_connection_endpoint" "blue" {
+		service_id  = gsolaceclustermgr_broker.` + rname + `.id
+		name        = "blue"
+		access_type = "PUBLIC"
+		ports = {
+			serviceSmfTlsListenPort        = 55443
+			serviceManagementTlsListenPort = 943
+		}
+	}
+	resource "gsolaceclustermgr_connection_endpoint" "green" {
+		service_id  = gsolaceclustermgr_broker.` + rname + `.id
+		name        = "green"
+		access_type = "PUBLIC"
+		ports = {
+			serviceSmfTlsListenPort        = 55443
+			serviceManagementTlsListenPort = 943
+		}
+	}
+	resource "gsolaceclustermgr_connection_endpoint_dns_name" "` + rname + `" {
+		service_id             = gsolaceclustermgr_broker.` + rname + `.id
+		connection_endpoint_id = gsolaceclustermgr_connection_endpoint.` + endpoint + `.id
+		dns_name               = "` + dnsName + `"
+	}
+	`
+}
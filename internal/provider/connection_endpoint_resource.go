@@ -0,0 +1,455 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// connectionEndpointResourceModel maps the resource schema data.
+type connectionEndpointResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	ServiceId     types.String   `tfsdk:"service_id"`
+	Name          types.String   `tfsdk:"name"`
+	Description   types.String   `tfsdk:"description"`
+	AccessType    types.String   `tfsdk:"access_type"`
+	Ports         types.Map      `tfsdk:"ports"`
+	CreationState types.String   `tfsdk:"creation_state"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &connectionEndpointResource{}
+	_ resource.ResourceWithConfigure   = &connectionEndpointResource{}
+	_ resource.ResourceWithImportState = &connectionEndpointResource{}
+)
+
+// NewConnectionEndpointResource is a helper function to simplify the provider implementation.
+func NewConnectionEndpointResource() resource.Resource {
+	return &connectionEndpointResource{}
+}
+
+// connectionEndpointResource is the resource implementation.
+type connectionEndpointResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *connectionEndpointResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_endpoint"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *connectionEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure connection endpoint resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *connectionEndpointResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Service connection endpoint of an existing broker service, e.g. an additional private endpoint. Import using *service_id/endpoint_id*. The hostnames are listed in *service_connection_endpoints* of the broker",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the connection endpoint",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the connection endpoint",
+				Optional:            true,
+			},
+			"access_type": schema.StringAttribute{
+				MarkdownDescription: "The connectivity of the endpoint, PUBLIC or PRIVATE",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(missioncontrol.PUBLIC), string(missioncontrol.PRIVATE)),
+				},
+			},
+			"ports": schema.MapAttribute{
+				MarkdownDescription: "The port numbers by protocol, e.g. serviceSmfTlsListenPort = 55443. Use 0 to disable a port. serviceManagementTlsListenPort and serviceSmfTlsListenPort must be given",
+				ElementType:         types.Int32Type,
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(endpointProtocols...)),
+					mapvalidator.ValueInt32sAre(int32validator.Between(0, 65535)),
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the connection endpoint",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_state": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *connectionEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState connectionEndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	body := missioncontrol.CreateConnectionEndpointJSONRequestBody{
+		Name:        plannedState.Name.ValueString(),
+		Description: nullIfEmptyStringPtr(plannedState.Description),
+		AccessType:  missioncontrol.ConnectionEndpointAccessType(plannedState.AccessType.ValueString()),
+		Ports:       endpointPortsFromMap(ctx, plannedState.Ports, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Creating connection endpoint using %v", body))
+
+	createResp, err := r.cMProviderData.Client.CreateConnectionEndpointWithResponse(ctx, serviceId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connection endpoint",
+			"Could not create connection endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(createResp.Body)))
+	if createResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error creating connection endpoint",
+			fmt.Sprintf("Unexpected response code: %v\n%s", createResp.StatusCode(), parseErrorResponse(createResp.Body)),
+		)
+		return
+	}
+	endpointId := *(createResp.JSON202.Data.ResourceId)
+	tflog.Info(ctx, fmt.Sprintf("Connection endpoint %s on broker %s has been started", endpointId, serviceId))
+
+	// store the id right away, so a failed or timed out creation leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), endpointId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.waitForCreationState(ctx, serviceId, endpointId, &plannedState, createTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedState.ID = types.StringValue(endpointId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *connectionEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState connectionEndpointResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, currentState.ServiceId.ValueString(), currentState.ID.ValueString(), &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *connectionEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState connectionEndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only send what has changed
+	body := missioncontrol.UpdateConnectionEndpointJSONRequestBody{}
+	changed := false
+	if !plannedState.Name.Equal(currentState.Name) {
+		body.Name = plannedState.Name.ValueStringPointer()
+		changed = true
+	}
+	if !plannedState.Description.Equal(currentState.Description) {
+		description := plannedState.Description.ValueString()
+		body.Description = &description
+		changed = true
+	}
+	if !plannedState.AccessType.Equal(currentState.AccessType) {
+		accessType := missioncontrol.ConnectionEndpointAccessType(plannedState.AccessType.ValueString())
+		body.AccessType = &accessType
+		changed = true
+	}
+	if !plannedState.Ports.Equal(currentState.Ports) {
+		ports := endpointPortsFromMap(ctx, plannedState.Ports, &resp.Diagnostics)
+		body.Ports = &ports
+		changed = true
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	endpointId := plannedState.ID.ValueString()
+	if changed {
+		tflog.Info(ctx, fmt.Sprintf("Updating connection endpoint %s using %v", endpointId, body))
+		updateResp, err := r.cMProviderData.Client.UpdateConnectionEndpointWithResponse(ctx, serviceId, endpointId, body, r.cMProviderData.BearerReqEditorFn)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating connection endpoint",
+				"Could not update connection endpoint, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(updateResp.Body)))
+		if updateResp.StatusCode() != 202 {
+			resp.Diagnostics.AddError(
+				"Error updating connection endpoint",
+				fmt.Sprintf("Unexpected response code: %v\n%s", updateResp.StatusCode(), parseErrorResponse(updateResp.Body)),
+			)
+			return
+		}
+		waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(updateResp.JSON202.Data.Id), updateTimeout, "Error updating connection endpoint", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found := r.get(ctx, serviceId, endpointId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error updating connection endpoint",
+			fmt.Sprintf("Connection endpoint %s of broker service %s vanished", endpointId, serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *connectionEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState connectionEndpointResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := currentState.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := currentState.ServiceId.ValueString()
+	endpointId := currentState.ID.ValueString()
+	delResp, err := r.cMProviderData.Client.DeleteConnectionEndpointWithResponse(ctx, serviceId, endpointId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting connection endpoint",
+			"Could not delete connection endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
+	if delResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find connection endpoint %s of broker service %s", endpointId, serviceId))
+		// this is tolerable!
+		return
+	}
+	if delResp.StatusCode() != 202 {
+		resp.Diagnostics.AddError(
+			"Error deleting connection endpoint",
+			fmt.Sprintf("Unexpected response code: %v\n%s", delResp.StatusCode(), parseErrorResponse(delResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(delResp.JSON202.Data.Id), deleteTimeout, "Error deleting connection endpoint", &resp.Diagnostics)
+}
+
+// ImportState imports an endpoint using service_id/endpoint_id.
+func (r *connectionEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceId, endpointId, ok := strings.Cut(req.ID, "/")
+	if !ok || serviceId == "" || endpointId == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/endpoint_id. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), endpointId)...)
+}
+
+// helper polling the endpoint until its creation state is completed (or failed)
+func (r *connectionEndpointResource) waitForCreationState(ctx context.Context, serviceId string, endpointId string, model *connectionEndpointResourceModel, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if time.Now().After(deadline) {
+			diagnostics.AddError(
+				"Timeout",
+				fmt.Sprintf("timeout waiting for connection endpoint %s on broker service %s", endpointId, serviceId),
+			)
+			return
+		}
+		if !sleepWithContext(ctx, r.cMProviderData.PollingIntervalDuration) {
+			diagnostics.AddError(
+				"Cancelled",
+				fmt.Sprintf("cancelled while waiting for connection endpoint %s on broker service %s", endpointId, serviceId),
+			)
+			return
+		}
+
+		found := r.get(ctx, serviceId, endpointId, model, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+		if !found {
+			diagnostics.AddError(
+				"Error creating connection endpoint",
+				fmt.Sprintf("Connection endpoint %s of broker service %s vanished", endpointId, serviceId),
+			)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Connection endpoint %s creation state %s", endpointId, model.CreationState.ValueString()))
+		switch missioncontrol.CreationState(model.CreationState.ValueString()) {
+		case missioncontrol.Completed:
+			return
+		case missioncontrol.Failed:
+			diagnostics.AddError(
+				"Error creating connection endpoint",
+				fmt.Sprintf("Connection endpoint %s of broker service %s has creation state %s", endpointId, serviceId, model.CreationState.ValueString()),
+			)
+			return
+		}
+	}
+}
+
+// helper reading the endpoint into the model, returns false if it does not exist
+func (r *connectionEndpointResource) get(ctx context.Context, serviceId string, endpointId string, model *connectionEndpointResourceModel, diagnostics *diag.Diagnostics) bool {
+	getResp, err := r.cMProviderData.Client.GetConnectionEndpointWithResponse(ctx, serviceId, endpointId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting connection endpoint",
+			"Could not get connection endpoint, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return false
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting connection endpoint",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return false
+	}
+
+	endpoint := getResp.JSON200.Data
+	model.ID = types.StringPointerValue(endpoint.Id)
+	model.ServiceId = types.StringValue(serviceId)
+	model.Name = types.StringValue(endpoint.Name)
+	// keep null descriptions null
+	if endpoint.Description != nil && *endpoint.Description != "" {
+		model.Description = types.StringPointerValue(endpoint.Description)
+	} else if !model.Description.IsNull() {
+		model.Description = types.StringValue("")
+	}
+	model.AccessType = types.StringValue(string(endpoint.AccessType))
+	model.Ports = endpointPortsValue(ctx, endpoint.Ports, model.Ports, diagnostics)
+	if endpoint.CreationState != nil {
+		model.CreationState = types.StringValue(string(*endpoint.CreationState))
+	} else {
+		model.CreationState = types.StringValue("")
+	}
+	return true
+}
+
+// helper converting the api ports to a map protocol -> port. Disabled ports are only kept when they are known
+// from the prior value, so the defaults of the server do not cause diffs
+func endpointPortsValue(ctx context.Context, ports []missioncontrol.ServiceConnectionEndpointPort, prior types.Map, diagnostics *diag.Diagnostics) types.Map {
+	priorPorts := map[string]basetypes.Int32Value{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diagnostics.Append(prior.ElementsAs(ctx, &priorPorts, false)...)
+	}
+	portMap := map[string]int32{}
+	for _, p := range ports {
+		var port int32
+		if p.Port != nil {
+			port = *p.Port
+		}
+		if _, known := priorPorts[string(p.Protocol)]; port == 0 && !known {
+			continue
+		}
+		portMap[string(p.Protocol)] = port
+	}
+	result, diags := types.MapValueFrom(ctx, types.Int32Type, portMap)
+	diagnostics.Append(diags...)
+	return result
+}
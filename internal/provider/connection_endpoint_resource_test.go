@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccConnectionEndpointResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// unknown access types are rejected
+			{
+				Config:      testConnectionEndpointConfig("ep1", "ocs-prov-ep1", "INTERNAL", 55443),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// Create and Read testing
+			{
+				Config: testConnectionEndpointConfig("ep1", "ocs-prov-ep1", "PRIVATE", 55443),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_connection_endpoint.ep1",
+						tfjsonpath.New("creation_state"),
+						knownvalue.StringExact("completed"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_connection_endpoint.ep1",
+						tfjsonpath.New("ports").AtMapKey("serviceSmfTlsListenPort"),
+						knownvalue.Int32Exact(55443),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "gsolaceclustermgr_connection_endpoint.ep1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testConnectionEndpointImportId("gsolaceclustermgr_connection_endpoint.ep1"),
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testConnectionEndpointConfig("ep1", "ocs-prov-ep1", "PUBLIC", 55444),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_connection_endpoint.ep1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_connection_endpoint.ep1",
+						tfjsonpath.New("access_type"),
+						knownvalue.StringExact("PUBLIC"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_connection_endpoint.ep1",
+						tfjsonpath.New("ports").AtMapKey("serviceSmfTlsListenPort"),
+						knownvalue.Int32Exact(55444),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testConnectionEndpointImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return rs.Primary.Attributes["service_id"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func testConnectionEndpointConfig(rname string, brokerName string, accessType string, smfPort int) string {
+	return providerConfig + `
+	resource "gsolaceclustermgr_broker" "` + rname + `" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "` + brokerName + `"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_connection_endpoint" "` + rname + `" {
+		service_id  = gsolaceclustermgr_broker.` + rname + `.id
+		name        = "private"
+		description = "private endpoint"
+		access_type = "` + accessType + `"
+		ports = {
+			serviceSmfTlsListenPort        = ` + fmt.Sprint(smfPort) + `
+			serviceManagementTlsListenPort = 943
+		}
+	}
+	`
+}
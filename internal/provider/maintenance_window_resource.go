@@ -0,0 +1,423 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maintenanceWindowResourceModel maps the resource schema data.
+type maintenanceWindowResourceModel struct {
+	ID                         types.String `tfsdk:"id"`
+	Name                       types.String `tfsdk:"name"`
+	MaintenanceScheduleId      types.String `tfsdk:"maintenance_schedule_id"`
+	DurationInHours            types.Int32  `tfsdk:"duration_in_hours"`
+	RecurrenceFrequency        types.String `tfsdk:"recurrence_frequency"`
+	RecurrenceDayOfWeek        types.String `tfsdk:"recurrence_day_of_week"`
+	RecurrenceDayWeekly        types.String `tfsdk:"recurrence_day_weekly"`
+	RecurrenceDayOfWeekMonthly types.String `tfsdk:"recurrence_day_of_week_monthly"`
+	StartTime                  types.String `tfsdk:"start_time"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &maintenanceWindowResource{}
+	_ resource.ResourceWithConfigure      = &maintenanceWindowResource{}
+	_ resource.ResourceWithImportState    = &maintenanceWindowResource{}
+	_ resource.ResourceWithValidateConfig = &maintenanceWindowResource{}
+)
+
+// NewMaintenanceWindowResource is a helper function to simplify the provider implementation.
+func NewMaintenanceWindowResource() resource.Resource {
+	return &maintenanceWindowResource{}
+}
+
+// maintenanceWindowResource is the resource implementation.
+type maintenanceWindowResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *maintenanceWindowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *maintenanceWindowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure maintenance window resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *maintenanceWindowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Recurring maintenance window of a maintenance schedule, e.g. to schedule broker upgrades into. All attributes can be updated in place",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the maintenance window",
+				Optional:            true,
+			},
+			"maintenance_schedule_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the maintenance schedule the maintenance window belongs to",
+				Required:            true,
+			},
+			"duration_in_hours": schema.Int32Attribute{
+				MarkdownDescription: "The duration of the maintenance window in hours",
+				Required:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"recurrence_frequency": schema.StringAttribute{
+				MarkdownDescription: "The frequency of the maintenance window, WEEKLY or MONTHLY",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceFrequencyWEEKLY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceFrequencyMONTHLY),
+					),
+				},
+			},
+			"recurrence_day_of_week": schema.StringAttribute{
+				MarkdownDescription: "The day of the week of the maintenance window, MONDAY to SUNDAY",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMONDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekTUESDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekWEDNESDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekTHURSDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekFRIDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekSATURDAY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekSUNDAY),
+					),
+				},
+			},
+			"recurrence_day_weekly": schema.StringAttribute{
+				MarkdownDescription: "The weeks between occurrences, EVERY, EVERY_OTHER, EVERY_THREE or EVERY_FOUR. Required for WEEKLY windows, not allowed for MONTHLY windows",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayWeeklyEVERY),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayWeeklyEVERYOTHER),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayWeeklyEVERYTHREE),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayWeeklyEVERYFOUR),
+					),
+				},
+			},
+			"recurrence_day_of_week_monthly": schema.StringAttribute{
+				MarkdownDescription: "The occurrence of *recurrence_day_of_week* within a month, FIRST, SECOND, THIRD or LAST. Required for MONTHLY windows, not allowed for WEEKLY windows",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMonthlyFIRST),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMonthlySECOND),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMonthlyTHIRD),
+						string(missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMonthlyLAST),
+					),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "The start of the first maintenance window as RFC3339 timestamp, e.g. \"2025-01-04T22:00:00Z\"",
+				Required:            true,
+				Validators:          []validator.String{rfc3339Validator{}},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the maintenance window",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the recurrence attributes match the recurrence frequency.
+func (r *maintenanceWindowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config maintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.RecurrenceFrequency.IsUnknown() || config.RecurrenceFrequency.IsNull() {
+		return
+	}
+
+	weekly := config.RecurrenceFrequency.ValueString() == string(missioncontrol.MaintenanceWindowRequestRecurrenceFrequencyWEEKLY)
+	checks := []struct {
+		name     string
+		value    types.String
+		required bool
+	}{
+		{"recurrence_day_weekly", config.RecurrenceDayWeekly, weekly},
+		{"recurrence_day_of_week_monthly", config.RecurrenceDayOfWeekMonthly, !weekly},
+	}
+	for _, check := range checks {
+		if check.value.IsUnknown() {
+			continue
+		}
+		if check.required && check.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(check.name),
+				"Missing Attribute Configuration",
+				fmt.Sprintf("%s is required for recurrence_frequency %s", check.name, config.RecurrenceFrequency.ValueString()),
+			)
+		}
+		if !check.required && !check.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(check.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s is not supported for recurrence_frequency %s", check.name, config.RecurrenceFrequency.ValueString()),
+			)
+		}
+	}
+}
+
+// Create creates the maintenance window.
+func (r *maintenanceWindowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState maintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := maintenanceWindowRequest(&plannedState)
+	tflog.Info(ctx, fmt.Sprintf("Creating maintenance window using %v", body))
+	createResp, err := r.cMProviderData.Client.CreateMaintenanceWindowWithResponse(ctx, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating maintenance window",
+			"Could not create maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(createResp.Body)))
+	if createResp.StatusCode() != 201 {
+		resp.Diagnostics.AddError(
+			"Error creating maintenance window",
+			fmt.Sprintf("Unexpected response code: %v\n%s", createResp.StatusCode(), parseErrorResponse(createResp.Body)),
+		)
+		return
+	}
+
+	maintenanceWindowToModel(createResp.JSON201.Data, &plannedState)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *maintenanceWindowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState maintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, currentState.ID.ValueString(), &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the maintenance window in place.
+func (r *maintenanceWindowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState maintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := plannedState.ID.ValueString()
+	body := maintenanceWindowRequest(&plannedState)
+	tflog.Info(ctx, fmt.Sprintf("Updating maintenance window %s using %v", id, body))
+	updateResp, err := r.cMProviderData.Client.UpdateMaintenanceWindowWithResponse(ctx, id, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating maintenance window",
+			"Could not update maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(updateResp.Body)))
+	if updateResp.StatusCode() != 200 {
+		resp.Diagnostics.AddError(
+			"Error updating maintenance window",
+			fmt.Sprintf("Unexpected response code: %v\n%s", updateResp.StatusCode(), parseErrorResponse(updateResp.Body)),
+		)
+		return
+	}
+
+	maintenanceWindowToModel(updateResp.JSON200.Data, &plannedState)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the maintenance window and removes the Terraform state on success.
+func (r *maintenanceWindowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState maintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := currentState.ID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Deleting maintenance window %s", id))
+	deleteResp, err := r.cMProviderData.Client.DeleteMaintenanceWindowWithResponse(ctx, id, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting maintenance window",
+			"Could not delete maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(deleteResp.Body)))
+	if deleteResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find maintenance window %s", id))
+		// this is tolerable!
+		return
+	}
+	if deleteResp.StatusCode() != 204 {
+		resp.Diagnostics.AddError(
+			"Error deleting maintenance window",
+			fmt.Sprintf("Unexpected response code: %v\n%s", deleteResp.StatusCode(), parseErrorResponse(deleteResp.Body)),
+		)
+	}
+}
+
+func (r *maintenanceWindowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// helper reading the maintenance window into the model, returns false if it does not exist
+func (r *maintenanceWindowResource) get(ctx context.Context, id string, model *maintenanceWindowResourceModel, diagnostics *diag.Diagnostics) bool {
+	getResp, err := r.cMProviderData.Client.GetMaintenanceWindowWithResponse(ctx, id, nil, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting maintenance window",
+			"Could not get maintenance window, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return false
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting maintenance window",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return false
+	}
+	maintenanceWindowToModel(getResp.JSON200.Data, model)
+	return true
+}
+
+// helper converting the model to the api request, the config has been validated already
+func maintenanceWindowRequest(model *maintenanceWindowResourceModel) missioncontrol.MaintenanceWindowRequest {
+	startTime, _ := time.Parse(time.RFC3339, model.StartTime.ValueString())
+	body := missioncontrol.MaintenanceWindowRequest{
+		Name:                  model.Name.ValueStringPointer(),
+		MaintenanceScheduleId: model.MaintenanceScheduleId.ValueString(),
+		DurationInHours:       model.DurationInHours.ValueInt32(),
+		RecurrenceFrequency:   missioncontrol.MaintenanceWindowRequestRecurrenceFrequency(model.RecurrenceFrequency.ValueString()),
+		RecurrenceDayOfWeek:   missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeek(model.RecurrenceDayOfWeek.ValueString()),
+		StartTime:             startTime,
+	}
+	if !model.RecurrenceDayWeekly.IsNull() {
+		dayWeekly := missioncontrol.MaintenanceWindowRequestRecurrenceDayWeekly(model.RecurrenceDayWeekly.ValueString())
+		body.RecurrenceDayWeekly = &dayWeekly
+	}
+	if !model.RecurrenceDayOfWeekMonthly.IsNull() {
+		dayOfWeekMonthly := missioncontrol.MaintenanceWindowRequestRecurrenceDayOfWeekMonthly(model.RecurrenceDayOfWeekMonthly.ValueString())
+		body.RecurrenceDayOfWeekMonthly = &dayOfWeekMonthly
+	}
+	return body
+}
+
+// helper converting the api maintenance window to the model
+func maintenanceWindowToModel(mw *missioncontrol.MaintenanceWindow, model *maintenanceWindowResourceModel) {
+	model.ID = types.StringPointerValue(mw.Id)
+	model.Name = types.StringPointerValue(mw.Name)
+	model.MaintenanceScheduleId = types.StringPointerValue(mw.MaintenanceScheduleId)
+	model.DurationInHours = types.Int32PointerValue(mw.DurationInHours)
+	model.RecurrenceFrequency = types.StringNull()
+	if mw.RecurrenceFrequency != nil {
+		model.RecurrenceFrequency = types.StringValue(string(*mw.RecurrenceFrequency))
+	}
+	model.RecurrenceDayOfWeek = types.StringNull()
+	if mw.RecurrenceDayOfWeek != nil {
+		model.RecurrenceDayOfWeek = types.StringValue(string(*mw.RecurrenceDayOfWeek))
+	}
+	model.RecurrenceDayWeekly = types.StringNull()
+	if mw.RecurrenceDayWeekly != nil {
+		model.RecurrenceDayWeekly = types.StringValue(string(*mw.RecurrenceDayWeekly))
+	}
+	model.RecurrenceDayOfWeekMonthly = types.StringNull()
+	if mw.RecurrenceDayOfWeekMonthly != nil {
+		model.RecurrenceDayOfWeekMonthly = types.StringValue(string(*mw.RecurrenceDayOfWeekMonthly))
+	}
+	// keep the configured notation of the start time, e.g. with an offset, as long as it is the same instant
+	if mw.StartTime == nil {
+		model.StartTime = types.StringNull()
+	} else if configured, err := time.Parse(time.RFC3339, model.StartTime.ValueString()); err != nil || !configured.Equal(*mw.StartTime) {
+		model.StartTime = types.StringValue(mw.StartTime.UTC().Format(time.RFC3339))
+	}
+}
+
+// rfc3339Validator checks that a string is a RFC3339 timestamp
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be a RFC3339 timestamp like \"2025-01-04T22:00:00Z\""
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
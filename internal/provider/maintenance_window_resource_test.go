@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func testAccCheckMaintenanceWindowDestroyed(s *terraform.State) error {
+	if svr == nil {
+		return nil
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gsolaceclustermgr_maintenance_window" {
+			continue
+		}
+		if svr.HasMaintenanceWindow(rs.Primary.ID) {
+			return fmt.Errorf("maintenance window %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func TestAccMaintenanceWindowResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMaintenanceWindowDestroyed,
+		Steps: []resource.TestStep{
+			// recurrence validation errors
+			{
+				Config:      testMaintenanceWindowConfig("mw1", "WEEKLY", `recurrence_day_of_week_monthly = "FIRST"`, "2025-01-04T22:00:00Z"),
+				ExpectError: regexp.MustCompile("recurrence_day_weekly is required for recurrence_frequency WEEKLY"),
+			},
+			{
+				Config:      testMaintenanceWindowConfig("mw1", "MONTHLY", `recurrence_day_weekly = "EVERY"`, "2025-01-04T22:00:00Z"),
+				ExpectError: regexp.MustCompile("recurrence_day_weekly is not supported for recurrence_frequency MONTHLY"),
+			},
+			{
+				Config:      testMaintenanceWindowConfig("mw1", "WEEKLY", `recurrence_day_weekly = "EVERY"`, "next saturday"),
+				ExpectError: regexp.MustCompile("Invalid Timestamp"),
+			},
+			// Create and Read testing, the offset of the start time does not cause diffs
+			{
+				Config: testMaintenanceWindowConfig("mw1", "WEEKLY", `recurrence_day_weekly = "EVERY_OTHER"`, "2025-01-04T23:00:00+01:00"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_maintenance_window.mw1",
+						tfjsonpath.New("start_time"),
+						knownvalue.StringExact("2025-01-04T23:00:00+01:00"),
+					),
+				},
+			},
+			// ImportState testing, the api returns the start time in UTC
+			{
+				ResourceName:            "gsolaceclustermgr_maintenance_window.mw1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start_time"},
+			},
+			// Update in place from weekly to monthly
+			{
+				Config: testMaintenanceWindowConfig("mw1", "MONTHLY", `recurrence_day_of_week_monthly = "LAST"`, "2025-01-04T22:00:00Z"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_maintenance_window.mw1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_maintenance_window.mw1",
+						tfjsonpath.New("recurrence_day_of_week_monthly"),
+						knownvalue.StringExact("LAST"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_maintenance_window.mw1",
+						tfjsonpath.New("recurrence_day_weekly"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testMaintenanceWindowConfig(rname string, frequency string, recurrence string, startTime string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_maintenance_window" "%[1]s" {
+		name                    = "saturday night"
+		maintenance_schedule_id = "schedule-1"
+		duration_in_hours       = 4
+		recurrence_frequency    = "%[2]s"
+		recurrence_day_of_week  = "SATURDAY"
+		%[3]s
+		start_time              = "%[4]s"
+	}
+	`, rname, frequency, recurrence, startTime)
+}
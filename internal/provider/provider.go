@@ -2,13 +2,17 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
 	"os"
+	"slices"
+	"strings"
 	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
 	"time"
 
-	"net/http"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -56,6 +60,84 @@ type CMProviderData struct {
 	PollingTimeoutDuration  time.Duration
 }
 
+// sensitiveKeyParts mark the keys of request and response bodies whose values are never logged, e.g. password
+// or missionControlManagerLoginCredentials.password. Keys are compared case insensitive
+var sensitiveKeyParts = []string{"password", "privatekey", "passphrase", "secret", "token"}
+
+// BearerReqEditorFn adds the bearer token auth header to requests and logs them at debug level.
+// The token is not logged and secrets in the body are redacted
+func (pd CMProviderData) BearerReqEditorFn(ctx context.Context, req *http.Request) error {
+	dump, err := httputil.DumpRequestOut(req, false)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Request: %s%s", dump, redactedBody(req)))
+	}
+	req.Header.Set("Authorization", "Bearer "+pd.BearerToken)
+	return nil
+}
+
+// helper returning the body of a request for logging, see redactedJSON
+func redactedBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return redactedJSON(b)
+}
+
+// helper returning a request or response body for logging with the values of sensitive keys redacted.
+// Bodies which are not JSON are left out
+func redactedJSON(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var obj interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return "<body not logged>"
+	}
+	redacted, err := json.Marshal(redact(obj))
+	if err != nil {
+		return "<body not logged>"
+	}
+	return string(redacted)
+}
+
+// helper replacing the values of sensitive keys in decoded JSON
+func redact(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveKey(key) {
+				v[key] = "***"
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return obj
+}
+
+// helper telling whether the value of a key must not be logged
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(sensitiveKeyParts, func(part string) bool {
+		return strings.Contains(key, part)
+	})
+}
+
 // Metadata returns the provider type name.
 func (p *clusterManagerProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "gsolaceclustermgr"
@@ -232,6 +314,9 @@ func (p *clusterManagerProvider) Configure(ctx context.Context, req provider.Con
 func (p *clusterManagerProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewBrokerDataSource,
+		NewClientProfilesDataSource,
+		NewSempObjectDataSource,
+		NewBrokerStateDataSource,
 	}
 }
 
@@ -239,5 +324,16 @@ func (p *clusterManagerProvider) DataSources(_ context.Context) []func() datasou
 func (p *clusterManagerProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBrokerResource,
+		NewConnectionEndpointResource,
+		NewConnectionEndpointDnsNameResource,
+		NewClientProfileResource,
+		NewSempObjectResource,
+		NewQueueResource,
+		NewAclProfileResource,
+		NewClientUsernameResource,
+		NewServerCertificateResource,
+		NewBrokerSwitchoverResource,
+		NewBrokerUpgradeResource,
+		NewMaintenanceWindowResource,
 	}
 }
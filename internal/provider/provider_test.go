@@ -1,8 +1,15 @@
 package provider
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -26,3 +33,39 @@ var (
 		"gsolaceclustermgr": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+func TestRedactedBody(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://localhost:8091", strings.NewReader(`{"clientUsername":"u1","password":"secret","certs":[{"privateKey":"pk","Passphrase":"pp"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"certs":[{"Passphrase":"***","privateKey":"***"}],"clientUsername":"u1","password":"***"}`, redactedBody(req))
+
+	req, err = http.NewRequest("POST", "http://localhost:8091", strings.NewReader("password=secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "<body not logged>", redactedBody(req))
+
+	req, err = http.NewRequest("GET", "http://localhost:8091", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", redactedBody(req))
+}
+
+func TestRedactedJSON(t *testing.T) {
+	assert.Equal(t, `{"data":{"broker":{"missionControlManagerLoginCredentials":{"password":"***","username":"admin"}},"name":"b1"}}`,
+		redactedJSON([]byte(`{"data":{"name":"b1","broker":{"missionControlManagerLoginCredentials":{"username":"admin","password":"pw"}}}}`)), "nested credentials")
+	assert.Equal(t, `{"authenticationBasicPassword":"***","clientSecret":"***","refreshToken":"***"}`,
+		redactedJSON([]byte(`{"authenticationBasicPassword":"pw","clientSecret":"s","refreshToken":"t"}`)), "keys containing a sensitive part")
+	assert.Equal(t, "<body not logged>", redactedJSON([]byte("<ErrorDTO><message>invalid</message></ErrorDTO>")), "not json")
+	assert.Equal(t, "", redactedJSON(nil), "no body")
+}
+
+func TestBearerReqEditorFn(t *testing.T) {
+	req, err := http.NewRequest("PUT", "http://localhost:8091", strings.NewReader(`{"password":"secret"}`))
+	assert.NoError(t, err)
+	assert.NoError(t, CMProviderData{BearerToken: "bt42"}.BearerReqEditorFn(context.Background(), req))
+	assert.Equal(t, "Bearer bt42", req.Header.Get("Authorization"))
+	// the body can still be sent after logging
+	body, err := req.GetBody()
+	assert.NoError(t, err)
+	b, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"password":"secret"}`, string(b))
+}
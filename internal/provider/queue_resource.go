@@ -0,0 +1,491 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// queueResourceModel maps the resource schema data.
+type queueResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ServiceId          types.String `tfsdk:"service_id"`
+	MsgVpnName         types.String `tfsdk:"msg_vpn_name"`
+	QueueName          types.String `tfsdk:"queue_name"`
+	AccessType         types.String `tfsdk:"access_type"`
+	Permission         types.String `tfsdk:"permission"`
+	Owner              types.String `tfsdk:"owner"`
+	MaxMsgSpoolUsage   types.Int64  `tfsdk:"max_msg_spool_usage"`
+	MaxRedeliveryCount types.Int64  `tfsdk:"max_redelivery_count"`
+	DeadMsgQueue       types.String `tfsdk:"dead_msg_queue"`
+	IngressEnabled     types.Bool   `tfsdk:"ingress_enabled"`
+	EgressEnabled      types.Bool   `tfsdk:"egress_enabled"`
+	Subscriptions      types.Set    `tfsdk:"subscriptions"`
+}
+
+// sempQueue is the SEMPv2 representation of a queue
+type sempQueue struct {
+	MsgVpnName         string  `json:"msgVpnName,omitempty"`
+	QueueName          string  `json:"queueName,omitempty"`
+	AccessType         *string `json:"accessType,omitempty"`
+	Permission         *string `json:"permission,omitempty"`
+	Owner              *string `json:"owner,omitempty"`
+	MaxMsgSpoolUsage   *int64  `json:"maxMsgSpoolUsage,omitempty"`
+	MaxRedeliveryCount *int64  `json:"maxRedeliveryCount,omitempty"`
+	DeadMsgQueue       *string `json:"deadMsgQueue,omitempty"`
+	IngressEnabled     *bool   `json:"ingressEnabled,omitempty"`
+	EgressEnabled      *bool   `json:"egressEnabled,omitempty"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &queueResource{}
+	_ resource.ResourceWithConfigure   = &queueResource{}
+	_ resource.ResourceWithImportState = &queueResource{}
+)
+
+// NewQueueResource is a helper function to simplify the provider implementation.
+func NewQueueResource() resource.Resource {
+	return &queueResource{}
+}
+
+// queueResource is the resource implementation.
+type queueResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *queueResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queue"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *queueResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure queue resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *queueResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Queue of a broker service, managed through the mission control SEMP proxy. Settings which are not configured keep the value of the broker. Import using *service_id/msg_vpn_name/queue_name*",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"msg_vpn_name": schema.StringAttribute{
+				MarkdownDescription: "The message VPN of the broker service, see *msg_vpn_name* of the broker",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"queue_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the queue",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 200),
+				},
+			},
+			"access_type": schema.StringAttribute{
+				MarkdownDescription: "exclusive or non-exclusive",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("exclusive", "non-exclusive"),
+				},
+			},
+			"permission": schema.StringAttribute{
+				MarkdownDescription: "The permission of clients other than the owner: no-access, read-only, consume, modify-topic or delete",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("no-access", "read-only", "consume", "modify-topic", "delete"),
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "The client username owning the queue",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_msg_spool_usage": schema.Int64Attribute{
+				MarkdownDescription: "The maximum message spool usage of the queue in MB",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_redelivery_count": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of redeliveries before a message is discarded or moved to the DMQ, 0 means retry forever",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+			},
+			"dead_msg_queue": schema.StringAttribute{
+				MarkdownDescription: "The name of the dead message queue (DMQ)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ingress_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether messages are accepted by the queue",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"egress_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether messages are delivered from the queue",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subscriptions": schema.SetAttribute{
+				MarkdownDescription: "The topic subscriptions of the queue. Each subscription is added or removed individually",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 250)),
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "service_id/msg_vpn_name/queue_name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *queueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState queueResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := queueSettings(plannedState, nil)
+	body.MsgVpnName = plannedState.MsgVpnName.ValueString()
+	body.QueueName = plannedState.QueueName.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Creating queue %s", body.QueueName))
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPost, plannedState.ServiceId.ValueString(), "msgVpns/"+url.PathEscape(body.MsgVpnName)+"/queues", body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating queue",
+			"Could not create queue, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error creating queue",
+			sempResp.errorMessage(),
+		)
+		return
+	}
+
+	// store the keys right away, so failing subscriptions leave a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), plannedState.ServiceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("msg_vpn_name"), plannedState.MsgVpnName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("queue_name"), plannedState.QueueName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcileSubscriptions(ctx, plannedState, types.SetNull(types.StringType), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error creating queue",
+			fmt.Sprintf("Queue %s vanished", plannedState.QueueName.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *queueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState queueResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *queueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState queueResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only send what has changed
+	body := queueSettings(plannedState, &currentState)
+	if body != (sempQueue{}) {
+		tflog.Info(ctx, fmt.Sprintf("Updating queue %s using %v", plannedState.QueueName.ValueString(), body))
+		sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPatch, plannedState.ServiceId.ValueString(), queuePath(plannedState), body, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating queue",
+				"Could not update queue, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !sempResp.ok() {
+			resp.Diagnostics.AddError(
+				"Error updating queue",
+				sempResp.errorMessage(),
+			)
+			return
+		}
+	}
+
+	r.reconcileSubscriptions(ctx, plannedState, currentState.Subscriptions, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, &plannedState, &resp.Diagnostics)
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error updating queue",
+			fmt.Sprintf("Queue %s vanished", plannedState.QueueName.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *queueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState queueResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the subscriptions are removed along with the queue
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodDelete, currentState.ServiceId.ValueString(), queuePath(currentState), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting queue",
+			"Could not delete queue, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if sempResp.notFound() {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find queue %s", currentState.QueueName.ValueString()))
+		// this is tolerable!
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error deleting queue",
+			sempResp.errorMessage(),
+		)
+	}
+}
+
+// ImportState imports a queue using service_id/msg_vpn_name/queue_name.
+func (r *queueResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.SplitN(req.ID, "/", 3)
+	if len(ids) != 3 || slices.Contains(ids, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/msg_vpn_name/queue_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("msg_vpn_name"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("queue_name"), ids[2])...)
+}
+
+// helper adding the planned subscriptions missing in prior and removing the ones no longer planned
+func (r *queueResource) reconcileSubscriptions(ctx context.Context, plan queueResourceModel, prior types.Set, diagnostics *diag.Diagnostics) {
+	if plan.Subscriptions.IsUnknown() || plan.Subscriptions.IsNull() {
+		return
+	}
+	var planned, current []string
+	diagnostics.Append(plan.Subscriptions.ElementsAs(ctx, &planned, false)...)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diagnostics.Append(prior.ElementsAs(ctx, &current, false)...)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	sempReconcile(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, plan.ServiceId.ValueString(), queuePath(plan)+"/subscriptions", planned, current,
+		url.PathEscape,
+		func(topic string) map[string]string {
+			return map[string]string{
+				"msgVpnName":        plan.MsgVpnName.ValueString(),
+				"queueName":         plan.QueueName.ValueString(),
+				"subscriptionTopic": topic,
+			}
+		},
+		"Error updating queue subscriptions", diagnostics)
+}
+
+// helper reading the queue and its subscriptions into the model, returns false if it does not exist
+func (r *queueResource) get(ctx context.Context, model *queueResourceModel, diagnostics *diag.Diagnostics) bool {
+	serviceId := model.ServiceId.ValueString()
+	var queue sempQueue
+	found := sempGetObject(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, queuePath(*model), &queue, "Error getting queue", diagnostics)
+	if diagnostics.HasError() || !found {
+		return false
+	}
+
+	subscriptions, found := sempList(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, queuePath(*model)+"/subscriptions", nil, "Error getting queue subscriptions", diagnostics)
+	if diagnostics.HasError() || !found {
+		return false
+	}
+	topics := sempStrings(subscriptions, "subscriptionTopic")
+
+	model.ID = types.StringValue(serviceId + "/" + model.MsgVpnName.ValueString() + "/" + model.QueueName.ValueString())
+	model.AccessType = types.StringPointerValue(queue.AccessType)
+	model.Permission = types.StringPointerValue(queue.Permission)
+	model.Owner = types.StringPointerValue(queue.Owner)
+	model.MaxMsgSpoolUsage = types.Int64PointerValue(queue.MaxMsgSpoolUsage)
+	model.MaxRedeliveryCount = types.Int64PointerValue(queue.MaxRedeliveryCount)
+	model.DeadMsgQueue = types.StringPointerValue(queue.DeadMsgQueue)
+	model.IngressEnabled = types.BoolPointerValue(queue.IngressEnabled)
+	model.EgressEnabled = types.BoolPointerValue(queue.EgressEnabled)
+	var diags diag.Diagnostics
+	model.Subscriptions, diags = types.SetValueFrom(ctx, types.StringType, topics)
+	diagnostics.Append(diags...)
+	return !diagnostics.HasError()
+}
+
+// helper returning the SEMP path of the queue
+func queuePath(model queueResourceModel) string {
+	return "msgVpns/" + url.PathEscape(model.MsgVpnName.ValueString()) + "/queues/" + url.PathEscape(model.QueueName.ValueString())
+}
+
+// helper collecting the known settings of the plan. If a prior state is given, only the changed settings are collected
+func queueSettings(plan queueResourceModel, prior *queueResourceModel) sempQueue {
+	var settings sempQueue
+	var current queueResourceModel
+	if prior != nil {
+		current = *prior
+	}
+	// unknown values are not configured and taken from the broker
+	if known(plan.AccessType) && (prior == nil || !plan.AccessType.Equal(current.AccessType)) {
+		settings.AccessType = plan.AccessType.ValueStringPointer()
+	}
+	if known(plan.Permission) && (prior == nil || !plan.Permission.Equal(current.Permission)) {
+		settings.Permission = plan.Permission.ValueStringPointer()
+	}
+	if known(plan.Owner) && (prior == nil || !plan.Owner.Equal(current.Owner)) {
+		settings.Owner = plan.Owner.ValueStringPointer()
+	}
+	if known(plan.MaxMsgSpoolUsage) && (prior == nil || !plan.MaxMsgSpoolUsage.Equal(current.MaxMsgSpoolUsage)) {
+		settings.MaxMsgSpoolUsage = plan.MaxMsgSpoolUsage.ValueInt64Pointer()
+	}
+	if known(plan.MaxRedeliveryCount) && (prior == nil || !plan.MaxRedeliveryCount.Equal(current.MaxRedeliveryCount)) {
+		settings.MaxRedeliveryCount = plan.MaxRedeliveryCount.ValueInt64Pointer()
+	}
+	if known(plan.DeadMsgQueue) && (prior == nil || !plan.DeadMsgQueue.Equal(current.DeadMsgQueue)) {
+		settings.DeadMsgQueue = plan.DeadMsgQueue.ValueStringPointer()
+	}
+	if known(plan.IngressEnabled) && (prior == nil || !plan.IngressEnabled.Equal(current.IngressEnabled)) {
+		settings.IngressEnabled = plan.IngressEnabled.ValueBoolPointer()
+	}
+	if known(plan.EgressEnabled) && (prior == nil || !plan.EgressEnabled.Equal(current.EgressEnabled)) {
+		settings.EgressEnabled = plan.EgressEnabled.ValueBoolPointer()
+	}
+	return settings
+}
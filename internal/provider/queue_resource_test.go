@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccQueueResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// unknown access types are rejected
+			{
+				Config:      testQueueConfig("q1", "ocs-prov-q1", "shared", 100, `["orders/>"]`),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// Create and Read testing, settings not configured are taken from the broker
+			{
+				Config: testQueueConfig("q1", "ocs-prov-q1", "non-exclusive", 100, `["orders/>", "returns/>"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_queue.q1",
+						tfjsonpath.New("permission"),
+						knownvalue.StringExact("no-access"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_queue.q1",
+						tfjsonpath.New("subscriptions"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("orders/>"), knownvalue.StringExact("returns/>")}),
+					),
+				},
+			},
+			// the defaults of the broker do not cause diffs
+			{
+				Config: testQueueConfig("q1", "ocs-prov-q1", "non-exclusive", 100, `["orders/>", "returns/>"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "gsolaceclustermgr_queue.q1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update in place, subscriptions are reconciled one by one
+			{
+				Config: testQueueConfig("q1", "ocs-prov-q1", "non-exclusive", 200, `["orders/>", "invoices/>"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_queue.q1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_queue.q1",
+						tfjsonpath.New("max_msg_spool_usage"),
+						knownvalue.Int64Exact(200),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_queue.q1",
+						tfjsonpath.New("subscriptions"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("orders/>"), knownvalue.StringExact("invoices/>")}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testQueueConfig(rname string, brokerName string, accessType string, maxSpool int, subscriptions string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_queue" "%[1]s" {
+		service_id          = gsolaceclustermgr_broker.%[1]s.id
+		msg_vpn_name        = gsolaceclustermgr_broker.%[1]s.msg_vpn_name
+		queue_name          = "orders"
+		access_type         = "%[3]s"
+		max_msg_spool_usage = %[4]d
+		ingress_enabled     = true
+		egress_enabled      = true
+		subscriptions       = %[5]s
+	}
+	`, rname, brokerName, accessType, maxSpool, subscriptions)
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type sempObjectDataSourceModel struct {
+	ServiceId    types.String  `tfsdk:"service_id"`
+	ResourcePath types.String  `tfsdk:"resource_path"`
+	Query        types.Map     `tfsdk:"query"`
+	Result       types.Dynamic `tfsdk:"result"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sempObjectDataSource{}
+	_ datasource.DataSourceWithConfigure = &sempObjectDataSource{}
+)
+
+// NewSempObjectDataSource is a helper function to simplify the provider implementation.
+func NewSempObjectDataSource() datasource.DataSource {
+	return &sempObjectDataSource{}
+}
+
+// sempObjectDataSource is the data source implementation.
+type sempObjectDataSource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the data source type name.
+func (d *sempObjectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_semp_object"
+}
+
+// Schema defines the schema for the data source.
+func (d *sempObjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Any SEMPv2 config object or collection of a broker service, read through the mission control SEMP proxy",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+			},
+			"resource_path": schema.StringAttribute{
+				MarkdownDescription: "The SEMPv2 config path, relative to /SEMP/v2/config. Either an object like msgVpns/default or a collection like msgVpns/default/queues. " +
+					"Names have to be url encoded as in SEMP",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(sempPathRegex, "must be a SEMP path like msgVpns/default/queues"),
+				},
+			},
+			"query": schema.MapAttribute{
+				MarkdownDescription: "SEMP query parameters like select, where or count. All pages of a collection are read, count only sets the page size",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"result": schema.DynamicAttribute{
+				MarkdownDescription: "The decoded SEMP object, or the list of objects of a collection",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read resource information.
+func (d *sempObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var currentState sempObjectDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	serviceId := currentState.ServiceId.ValueString()
+	resourcePath := currentState.ResourcePath.ValueString()
+
+	var params map[string]string
+	resp.Diagnostics.Append(currentState.Query.ElementsAs(ctx, &params, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	query := map[string]interface{}{}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	// SEMP paths alternate between collections and object names
+	var result interface{}
+	var found bool
+	if len(strings.Split(resourcePath, "/"))%2 == 1 {
+		var objects []map[string]interface{}
+		objects, found = sempList(ctx, d.cMProviderData, d.cMProviderData.BearerReqEditorFn, serviceId, resourcePath, query, "Error getting SEMP objects", &resp.Diagnostics)
+		list := make([]interface{}, 0, len(objects))
+		for _, obj := range objects {
+			list = append(list, obj)
+		}
+		result = list
+	} else {
+		var obj map[string]interface{}
+		found = sempGetObject(ctx, d.cMProviderData, d.cMProviderData.BearerReqEditorFn, serviceId, resourcePath, &obj, "Error getting SEMP object", &resp.Diagnostics)
+		result = obj
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error getting SEMP object",
+			fmt.Sprintf("Could not find %s of broker %s", resourcePath, serviceId),
+		)
+		return
+	}
+
+	value := jsonValue(result, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	currentState.Result = types.DynamicValue(value)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *sempObjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "configure semp object datasource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cMProviderData = cMProviderData
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccSempObjectDataSource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// an object and a collection read page by page
+			{
+				Config: testQueueConfig("sds1", "ocs-prov-sds1", "exclusive", 100, `["orders/>"]`) + `
+				data "gsolaceclustermgr_semp_object" "vpn" {
+					service_id    = gsolaceclustermgr_queue.sds1.service_id
+					resource_path = "msgVpns/${gsolaceclustermgr_queue.sds1.msg_vpn_name}"
+				}
+				data "gsolaceclustermgr_semp_object" "queues" {
+					service_id    = gsolaceclustermgr_queue.sds1.service_id
+					resource_path = "msgVpns/${gsolaceclustermgr_queue.sds1.msg_vpn_name}/queues"
+					query = {
+						select = "queueName,maxMsgSpoolUsage"
+						count  = "1"
+					}
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_semp_object.vpn",
+						tfjsonpath.New("result").AtMapKey("maxConnectionCount"),
+						knownvalue.NumberExact(big.NewFloat(100)),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_semp_object.queues",
+						tfjsonpath.New("result"),
+						knownvalue.TupleSizeExact(1),
+					),
+					statecheck.ExpectKnownValue(
+						"data.gsolaceclustermgr_semp_object.queues",
+						tfjsonpath.New("result").AtSliceIndex(0).AtMapKey("queueName"),
+						knownvalue.StringExact("orders"),
+					),
+				},
+			},
+		},
+	})
+}
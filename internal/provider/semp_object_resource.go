@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sempObjectResourceModel maps the resource schema data.
+type sempObjectResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ServiceId    types.String `tfsdk:"service_id"`
+	ResourcePath types.String `tfsdk:"resource_path"`
+	Body         types.String `tfsdk:"body"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &sempObjectResource{}
+	_ resource.ResourceWithConfigure   = &sempObjectResource{}
+	_ resource.ResourceWithImportState = &sempObjectResource{}
+)
+
+// NewSempObjectResource is a helper function to simplify the provider implementation.
+func NewSempObjectResource() resource.Resource {
+	return &sempObjectResource{}
+}
+
+// sempObjectResource is the resource implementation.
+type sempObjectResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *sempObjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_semp_object"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sempObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure semp object resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *sempObjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Any SEMPv2 config object of a broker service, managed through the mission control SEMP proxy. " +
+			"Only the keys given in *body* are compared with the broker. Removing a key from *body* replaces the object, which resets the key to its default. " +
+			"Import using *service_id/resource_path*",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_path": schema.StringAttribute{
+				MarkdownDescription: "The SEMPv2 config path of the object, relative to /SEMP/v2/config, e.g. msgVpns/default/queues/q1. " +
+					"Names have to be url encoded as in SEMP. The object is created by posting *body* to the parent path",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(sempObjectPathRegex, "must be a SEMP object path like msgVpns/default/queues/q1"),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The SEMP object as json, including its identifying attributes (e.g. msgVpnName and queueName), see jsonencode",
+				Required:            true,
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "service_id/resource_path",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *sempObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState sempObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := decodeJSONObject(plannedState.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid JSON Object", err.Error())
+		return
+	}
+	serviceId := plannedState.ServiceId.ValueString()
+	resourcePath := strings.Trim(plannedState.ResourcePath.ValueString(), "/")
+	collectionPath := resourcePath[:strings.LastIndex(resourcePath, "/")]
+	tflog.Info(ctx, fmt.Sprintf("Creating SEMP object %s", resourcePath))
+
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodPost, serviceId, collectionPath, body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SEMP object",
+			"Could not create SEMP object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error creating SEMP object",
+			sempResp.errorMessage(),
+		)
+		return
+	}
+
+	plannedState.ID = types.StringValue(serviceId + "/" + plannedState.ResourcePath.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sempObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState sempObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actual, found := r.get(ctx, currentState.ServiceId.ValueString(), currentState.ResourcePath.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// only the configured keys are tracked, an unchanged body is kept as written by the user
+	desired, err := decodeJSONObject(currentState.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid JSON Object", err.Error())
+		return
+	}
+	if projection := sempProjection(desired, actual); !reflect.DeepEqual(projection, desired) {
+		b, err := json.Marshal(projection)
+		if err != nil {
+			resp.Diagnostics.AddError("Internal error", "Could not encode SEMP object: "+err.Error())
+			return
+		}
+		currentState.Body = types.StringValue(string(b))
+	}
+	currentState.ID = types.StringValue(currentState.ServiceId.ValueString() + "/" + currentState.ResourcePath.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sempObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState sempObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, err := decodeJSONObject(plannedState.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid JSON Object", err.Error())
+		return
+	}
+	prior, err := decodeJSONObject(currentState.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid JSON Object", err.Error())
+		return
+	}
+
+	// patch what has changed, removed keys need a replace to be reset to their defaults
+	changed, removed := sempChanges(prior, planned)
+	method, body := http.MethodPatch, changed
+	if removed {
+		method, body = http.MethodPut, planned
+	}
+	if len(body) > 0 {
+		// the body is not logged, it may contain secrets like a password
+		tflog.Info(ctx, fmt.Sprintf("Updating SEMP object %s using %s", plannedState.ResourcePath.ValueString(), method))
+		sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, method, plannedState.ServiceId.ValueString(), plannedState.ResourcePath.ValueString(), body, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating SEMP object",
+				"Could not update SEMP object, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !sempResp.ok() {
+			resp.Diagnostics.AddError(
+				"Error updating SEMP object",
+				sempResp.errorMessage(),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sempObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState sempObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourcePath := currentState.ResourcePath.ValueString()
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodDelete, currentState.ServiceId.ValueString(), resourcePath, nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting SEMP object",
+			"Could not delete SEMP object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if sempResp.notFound() {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find SEMP object %s", resourcePath))
+		// this is tolerable!
+		return
+	}
+	if !sempResp.ok() {
+		resp.Diagnostics.AddError(
+			"Error deleting SEMP object",
+			sempResp.errorMessage(),
+		)
+	}
+}
+
+// ImportState imports an object using service_id/resource_path. The body is taken from the configuration on the next apply.
+func (r *sempObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceId, resourcePath, ok := strings.Cut(req.ID, "/")
+	if !ok || serviceId == "" || !sempObjectPathRegex.MatchString(resourcePath) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/resource_path. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_path"), resourcePath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("body"), "{}")...)
+}
+
+// helper reading the SEMP object, returns false if it does not exist
+func (r *sempObjectResource) get(ctx context.Context, serviceId string, resourcePath string, diagnostics *diag.Diagnostics) (map[string]interface{}, bool) {
+	sempResp, err := sempCall(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, http.MethodGet, serviceId, resourcePath, nil, nil)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting SEMP object",
+			"Could not get SEMP object, unexpected error: "+err.Error(),
+		)
+		return nil, false
+	}
+	if sempResp.notFound() {
+		return nil, false
+	}
+	if !sempResp.ok() {
+		diagnostics.AddError(
+			"Error getting SEMP object",
+			sempResp.errorMessage(),
+		)
+		return nil, false
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(sempResp.Data, &actual); err != nil || actual == nil {
+		diagnostics.AddError(
+			"Error getting SEMP object",
+			fmt.Sprintf("Could not decode SEMP object %s: %s", resourcePath, sempResp.Body),
+		)
+		return nil, false
+	}
+	return actual, true
+}
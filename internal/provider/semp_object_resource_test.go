@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccSempObjectResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// the body must be a json object
+			{
+				Config:      testSempObjectConfig("so1", "ocs-prov-so1", `"[]"`),
+				ExpectError: regexp.MustCompile("Invalid JSON Object"),
+			},
+			// Create and Read testing
+			{
+				Config: testSempObjectConfig("so1", "ocs-prov-so1", `jsonencode({ msgVpnName = gsolaceclustermgr_broker.so1.msg_vpn_name, queueName = "q1", maxMsgSpoolUsage = 100 })`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_semp_object.so1",
+						tfjsonpath.New("id"),
+						knownvalue.StringRegexp(regexp.MustCompile("/msgVpns/.+/queues/q1$")),
+					),
+				},
+			},
+			// the attributes filled in by the broker do not cause diffs
+			{
+				Config: testSempObjectConfig("so1", "ocs-prov-so1", `jsonencode({ msgVpnName = gsolaceclustermgr_broker.so1.msg_vpn_name, queueName = "q1", maxMsgSpoolUsage = 100 })`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "gsolaceclustermgr_semp_object.so1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"body"},
+			},
+			// Update in place
+			{
+				Config: testSempObjectConfig("so1", "ocs-prov-so1", `jsonencode({ msgVpnName = gsolaceclustermgr_broker.so1.msg_vpn_name, queueName = "q1", maxMsgSpoolUsage = 200, owner = "app1" })`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_semp_object.so1", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testSempObjectConfig(rname string, brokerName string, body string) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_semp_object" "%[1]s" {
+		service_id    = gsolaceclustermgr_broker.%[1]s.id
+		resource_path = "msgVpns/${gsolaceclustermgr_broker.%[1]s.msg_vpn_name}/queues/q1"
+		body          = %[3]s
+	}
+	`, rname, brokerName, body)
+}
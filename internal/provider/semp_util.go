@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sempPathRegex matches SEMP collection and object paths, e.g. msgVpns or msgVpns/default
+var sempPathRegex = regexp.MustCompile(`^[^/]+(/[^/]+)*$`)
+
+// sempObjectPathRegex matches SEMP object paths with at least a collection and a name, e.g. msgVpns/default
+var sempObjectPathRegex = regexp.MustCompile(`^[^/]+(/[^/]+)+$`)
+
+// sempResponse is a decoded response of the SEMP proxy
+type sempResponse struct {
+	StatusCode int             `json:"-"`
+	Body       []byte          `json:"-"`
+	Data       json.RawMessage `json:"data"`
+	Meta       struct {
+		Error *struct {
+			Code        interface{} `json:"code"`
+			Description string      `json:"description"`
+			Status      string      `json:"status"`
+		} `json:"error"`
+		Paging *struct {
+			CursorQuery string `json:"cursorQuery"`
+			NextPageUri string `json:"nextPageUri"`
+		} `json:"paging"`
+		ResponseCode int `json:"responseCode"`
+	} `json:"meta"`
+}
+
+// ok tells whether the SEMP request succeeded
+func (s *sempResponse) ok() bool {
+	return s.StatusCode >= 200 && s.StatusCode < 300 && s.Meta.Error == nil
+}
+
+// notFound tells whether the SEMP object does not exist
+func (s *sempResponse) notFound() bool {
+	return s.StatusCode == 404 || (s.Meta.Error != nil && s.Meta.Error.Status == "NOT_FOUND")
+}
+
+// errorMessage returns the SEMP error description, or the mission control error
+func (s *sempResponse) errorMessage() string {
+	if s.Meta.Error != nil && s.Meta.Error.Description != "" {
+		return fmt.Sprintf("Unexpected response code: %v\n%s (%s)", s.StatusCode, s.Meta.Error.Description, s.Meta.Error.Status)
+	}
+	return fmt.Sprintf("Unexpected response code: %v\n%s", s.StatusCode, parseErrorResponse(s.Body))
+}
+
+// helper creating a request editor which puts the resource path into the SEMP proxy url as is, e.g. msgVpns/default/queues/my%2Fqueue.
+// The generated client would escape the slashes and append the /** of the path template
+func sempPathEditor(resourcePath string) missioncontrol.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		prefix, _, found := strings.Cut(req.URL.EscapedPath(), "/SEMP/v2/config/")
+		if !found {
+			return fmt.Errorf("unexpected SEMP proxy url %s", req.URL)
+		}
+		rawPath := prefix + "/SEMP/v2/config/" + strings.Trim(resourcePath, "/")
+		unescaped, err := url.PathUnescape(rawPath)
+		if err != nil {
+			return err
+		}
+		req.URL.Path = unescaped
+		req.URL.RawPath = rawPath
+		return nil
+	}
+}
+
+// helper sending a request through the SEMP proxy. The raw client calls are used, as the generated
+// responses expect a json string instead of the SEMP object
+func sempCall(ctx context.Context, pd CMProviderData, bearerReqEditor missioncontrol.RequestEditorFn, method string, serviceId string, resourcePath string, body interface{}, query map[string]interface{}) (*sempResponse, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	editors := []missioncontrol.RequestEditorFn{sempPathEditor(resourcePath), bearerReqEditor}
+
+	var httpResp *http.Response
+	var err error
+	switch method {
+	case http.MethodGet:
+		var params *missioncontrol.GetResourceParams
+		if len(query) > 0 {
+			params = &missioncontrol.GetResourceParams{CustomQuery: &query}
+		}
+		httpResp, err = pd.Client.GetResource(ctx, serviceId, resourcePath, params, editors...)
+	case http.MethodPost:
+		httpResp, err = pd.Client.CreateResourceWithBody(ctx, serviceId, resourcePath, "application/json", reqBody, editors...)
+	case http.MethodPatch:
+		httpResp, err = pd.Client.PatchResourceWithBody(ctx, serviceId, resourcePath, "application/json", reqBody, editors...)
+	case http.MethodPut:
+		httpResp, err = pd.Client.ReplaceResourceWithBody(ctx, serviceId, resourcePath, "application/json", reqBody, editors...)
+	case http.MethodDelete:
+		httpResp, err = pd.Client.DeleteResource(ctx, serviceId, resourcePath, editors...)
+	default:
+		return nil, fmt.Errorf("unsupported SEMP method %s", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	result := sempResponse{StatusCode: httpResp.StatusCode}
+	result.Body, err = io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(result.Body)))
+	// errors of mission control itself are no SEMP responses
+	_ = json.Unmarshal(result.Body, &result)
+	return &result, nil
+}
+
+// helper decoding a json object, an empty string is taken as empty object
+func decodeJSONObject(s string) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	if strings.TrimSpace(s) == "" {
+		return obj, nil
+	}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("null is not a json object")
+	}
+	return obj, nil
+}
+
+// helper returning the keys of desired with the values of actual. Keys missing in actual, like write-only
+// passwords, keep their desired value
+func sempProjection(desired map[string]interface{}, actual map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range desired {
+		if av, ok := actual[k]; ok {
+			result[k] = av
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// helper returning the keys of plan whose values differ from prior, and whether keys were removed
+func sempChanges(prior map[string]interface{}, plan map[string]interface{}) (map[string]interface{}, bool) {
+	changed := map[string]interface{}{}
+	for k, v := range plan {
+		if pv, ok := prior[k]; !ok || !reflect.DeepEqual(pv, v) {
+			changed[k] = v
+		}
+	}
+	removed := false
+	for k := range prior {
+		if _, ok := plan[k]; !ok {
+			removed = true
+		}
+	}
+	return changed, removed
+}
+
+// jsonObjectValidator checks that a string is a json object
+type jsonObjectValidator struct{}
+
+func (v jsonObjectValidator) Description(_ context.Context) string {
+	return "value must be a json object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := decodeJSONObject(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			fmt.Sprintf("Attribute %s must be a json object: %s", req.Path, err),
+		)
+	}
+}
+
+// helper reading all objects of a SEMP collection, following the paging cursors. Returns false if the parent does not exist
+func sempList(ctx context.Context, pd CMProviderData, bearerReqEditor missioncontrol.RequestEditorFn, serviceId string, collectionPath string, query map[string]interface{}, summary string, diagnostics *diag.Diagnostics) ([]map[string]interface{}, bool) {
+	pageQuery := maps.Clone(query)
+	if pageQuery == nil {
+		pageQuery = map[string]interface{}{}
+	}
+	objects := []map[string]interface{}{}
+	for {
+		sempResp, err := sempCall(ctx, pd, bearerReqEditor, http.MethodGet, serviceId, collectionPath, nil, pageQuery)
+		if err != nil {
+			diagnostics.AddError(summary, "Could not get SEMP objects, unexpected error: "+err.Error())
+			return nil, false
+		}
+		if sempResp.notFound() {
+			return nil, false
+		}
+		if !sempResp.ok() {
+			diagnostics.AddError(summary, sempResp.errorMessage())
+			return nil, false
+		}
+		var page []map[string]interface{}
+		if err := json.Unmarshal(sempResp.Data, &page); err != nil {
+			diagnostics.AddError(summary, fmt.Sprintf("Could not decode SEMP objects of %s: %s", collectionPath, sempResp.Body))
+			return nil, false
+		}
+		objects = append(objects, page...)
+		if sempResp.Meta.Paging == nil || sempResp.Meta.Paging.CursorQuery == "" {
+			return objects, true
+		}
+		pageQuery["cursor"] = sempResp.Meta.Paging.CursorQuery
+	}
+}
+
+// helper reading a SEMP object into target, returns false if it does not exist
+func sempGetObject(ctx context.Context, pd CMProviderData, bearerReqEditor missioncontrol.RequestEditorFn, serviceId string, resourcePath string, target interface{}, summary string, diagnostics *diag.Diagnostics) bool {
+	sempResp, err := sempCall(ctx, pd, bearerReqEditor, http.MethodGet, serviceId, resourcePath, nil, nil)
+	if err != nil {
+		diagnostics.AddError(summary, "Could not get SEMP object, unexpected error: "+err.Error())
+		return false
+	}
+	if sempResp.notFound() {
+		return false
+	}
+	if !sempResp.ok() {
+		diagnostics.AddError(summary, sempResp.errorMessage())
+		return false
+	}
+	if err := json.Unmarshal(sempResp.Data, target); err != nil {
+		diagnostics.AddError(summary, fmt.Sprintf("Could not decode SEMP object %s: %s", resourcePath, sempResp.Body))
+		return false
+	}
+	return true
+}
+
+// helper reconciling the child objects of a collection one by one, e.g. the subscriptions of a queue:
+// the current ones no longer planned are deleted, the planned ones not yet existing are created
+func sempReconcile(ctx context.Context, pd CMProviderData, bearerReqEditor missioncontrol.RequestEditorFn, serviceId string, collectionPath string, planned []string, current []string,
+	objectId func(string) string, objectBody func(string) map[string]string, summary string, diagnostics *diag.Diagnostics) {
+	for _, key := range current {
+		if slices.Contains(planned, key) {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Removing %s from %s", key, collectionPath))
+		sempResp, err := sempCall(ctx, pd, bearerReqEditor, http.MethodDelete, serviceId, collectionPath+"/"+objectId(key), nil, nil)
+		if err != nil {
+			diagnostics.AddError(summary, fmt.Sprintf("Could not remove %s, unexpected error: %s", key, err.Error()))
+			return
+		}
+		if !sempResp.ok() && !sempResp.notFound() {
+			diagnostics.AddError(summary, sempResp.errorMessage())
+			return
+		}
+	}
+	for _, key := range planned {
+		if slices.Contains(current, key) {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Adding %s to %s", key, collectionPath))
+		sempResp, err := sempCall(ctx, pd, bearerReqEditor, http.MethodPost, serviceId, collectionPath, objectBody(key), nil)
+		if err != nil {
+			diagnostics.AddError(summary, fmt.Sprintf("Could not add %s, unexpected error: %s", key, err.Error()))
+			return
+		}
+		if !sempResp.ok() {
+			diagnostics.AddError(summary, sempResp.errorMessage())
+			return
+		}
+	}
+}
+
+// helper returning the string attribute of each object
+func sempStrings(objects []map[string]interface{}, key string) []string {
+	values := []string{}
+	for _, obj := range objects {
+		if value, ok := obj[key].(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// helper converting decoded json into a terraform value: objects become objects, arrays become tuples
+// as their elements may differ in type. Nulls are taken as null strings
+func jsonValue(v interface{}, diagnostics *diag.Diagnostics) attr.Value {
+	switch value := v.(type) {
+	case nil:
+		return types.StringNull()
+	case bool:
+		return types.BoolValue(value)
+	case float64:
+		return types.NumberValue(big.NewFloat(value))
+	case string:
+		return types.StringValue(value)
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(value))
+		elems := make([]attr.Value, 0, len(value))
+		for _, e := range value {
+			elem := jsonValue(e, diagnostics)
+			elemTypes = append(elemTypes, elem.Type(context.Background()))
+			elems = append(elems, elem)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		diagnostics.Append(diags...)
+		return tuple
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(value))
+		attrs := make(map[string]attr.Value, len(value))
+		for k, e := range value {
+			attrs[k] = jsonValue(e, diagnostics)
+			attrTypes[k] = attrs[k].Type(context.Background())
+		}
+		obj, diags := types.ObjectValue(attrTypes, attrs)
+		diagnostics.Append(diags...)
+		return obj
+	default:
+		diagnostics.AddError("Could not convert value", fmt.Sprintf("Unexpected json type %T", v))
+		return types.StringNull()
+	}
+}
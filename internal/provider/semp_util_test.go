@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSempPathEditor(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/api/v2/missionControl/eventBrokerServices/s1/broker/SEMP/v2/config/msgVpns%2Fdefault/**", nil)
+	assert.NoError(t, sempPathEditor("msgVpns/default/queues/a%2Fb")(context.Background(), req))
+	assert.Equal(t, "/api/v2/missionControl/eventBrokerServices/s1/broker/SEMP/v2/config/msgVpns/default/queues/a%2Fb", req.URL.EscapedPath())
+	assert.Equal(t, "/api/v2/missionControl/eventBrokerServices/s1/broker/SEMP/v2/config/msgVpns/default/queues/a/b", req.URL.Path)
+}
+
+func TestSempProjection(t *testing.T) {
+	desired := map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(100), "password": "secret"}
+	actual := map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(200), "owner": "app1"}
+	assert.Equal(t, map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(200), "password": "secret"},
+		sempProjection(desired, actual), "only desired keys, write-only keys kept")
+}
+
+func TestSempChanges(t *testing.T) {
+	prior := map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(100), "owner": "app1"}
+	changed, removed := sempChanges(prior, map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(200), "owner": "app1"})
+	assert.Equal(t, map[string]interface{}{"maxMsgSpoolUsage": float64(200)}, changed)
+	assert.False(t, removed)
+	changed, removed = sempChanges(prior, map[string]interface{}{"queueName": "q1", "maxMsgSpoolUsage": float64(100)})
+	assert.Empty(t, changed)
+	assert.True(t, removed)
+}
+
+func TestDecodeJSONObject(t *testing.T) {
+	obj, err := decodeJSONObject(`{"a": 1}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, obj)
+	obj, err = decodeJSONObject("")
+	assert.NoError(t, err)
+	assert.Empty(t, obj)
+	_, err = decodeJSONObject("[]")
+	assert.Error(t, err)
+	_, err = decodeJSONObject("null")
+	assert.Error(t, err)
+}
+
+func TestJsonValue(t *testing.T) {
+	var diags diag.Diagnostics
+	value := jsonValue(map[string]interface{}{
+		"queueName":   "q1",
+		"enabled":     true,
+		"count":       float64(2),
+		"owner":       nil,
+		"collections": []interface{}{"a", float64(1)},
+	}, &diags)
+	assert.False(t, diags.HasError())
+	obj, ok := value.(types.Object)
+	assert.True(t, ok)
+	assert.Equal(t, types.StringValue("q1"), obj.Attributes()["queueName"])
+	assert.Equal(t, types.BoolValue(true), obj.Attributes()["enabled"])
+	assert.Equal(t, types.NumberValue(big.NewFloat(2)), obj.Attributes()["count"])
+	assert.Equal(t, types.StringNull(), obj.Attributes()["owner"])
+	tuple, ok := obj.Attributes()["collections"].(types.Tuple)
+	assert.True(t, ok)
+	assert.Len(t, tuple.Elements(), 2)
+}
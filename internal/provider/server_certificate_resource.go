@@ -0,0 +1,548 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"terraform-provider-gsolaceclustermgr/internal/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// serverCertificateResourceModel maps the resource schema data.
+type serverCertificateResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	ServiceId       types.String   `tfsdk:"service_id"`
+	Certificate     types.String   `tfsdk:"certificate"`
+	PrivateKey      types.String   `tfsdk:"private_key"`
+	Passphrase      types.String   `tfsdk:"passphrase"`
+	Install         types.Bool     `tfsdk:"install"`
+	Installed       types.Bool     `tfsdk:"installed"`
+	CertificateType types.String   `tfsdk:"certificate_type"`
+	SubjectCN       types.String   `tfsdk:"subject_cn"`
+	SerialNumber    types.String   `tfsdk:"serial_number"`
+	Sha1Thumbprint  types.String   `tfsdk:"sha1_thumbprint"`
+	NotBefore       types.String   `tfsdk:"not_before"`
+	NotAfter        types.String   `tfsdk:"not_after"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &serverCertificateResource{}
+	_ resource.ResourceWithConfigure   = &serverCertificateResource{}
+	_ resource.ResourceWithImportState = &serverCertificateResource{}
+	_ resource.ResourceWithModifyPlan  = &serverCertificateResource{}
+)
+
+// NewServerCertificateResource is a helper function to simplify the provider implementation.
+func NewServerCertificateResource() resource.Resource {
+	return &serverCertificateResource{}
+}
+
+// serverCertificateResource is the resource implementation.
+type serverCertificateResource struct {
+	cMProviderData CMProviderData
+}
+
+// Metadata returns the resource type name.
+func (r *serverCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_certificate"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serverCertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "configure server certificate resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	cMProviderData, ok := req.ProviderData.(CMProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected CMProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cMProviderData = cMProviderData
+}
+
+// Schema defines the schema for the resource.
+func (r *serverCertificateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom server certificate of an existing broker service. A changed certificate or private key is rotated in place: " +
+			"the new certificate is uploaded (and installed) before the old one is deleted. Import using *service_id/certificate_id*, " +
+			"the certificate is only rotated after an import when its SHA1 thumbprint differs",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the broker service",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded certificate, optionally followed by the chain",
+				Required:            true,
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded private key of the certificate",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"passphrase": schema.StringAttribute{
+				MarkdownDescription: "The passphrase of an encrypted private key, used when installing the certificate",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"install": schema.BoolAttribute{
+				MarkdownDescription: "Install the certificate on the broker, replacing the installed one. false does not uninstall an installed certificate, " +
+					"but another certificate installed outside of terraform is detected and replaced when true",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// computed attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the server certificate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"installed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the certificate is installed on the broker",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the certificate, CUSTOM or SOLACE_MANAGED",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subject_cn": schema.StringAttribute{
+				MarkdownDescription: "The common name of the certificate subject",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				MarkdownDescription: "The serial number of the certificate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sha1_thumbprint": schema.StringAttribute{
+				MarkdownDescription: "The SHA1 thumbprint of the certificate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "Start of the validity of the certificate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_after": schema.StringAttribute{
+				MarkdownDescription: "End of the validity of the certificate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// ModifyPlan marks the computed attributes unknown when the certificate is rotated or installed.
+func (r *serverCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plannedState, currentState serverCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if certificateRotation(plannedState, currentState) {
+		tflog.Info(ctx, fmt.Sprintf("Server certificate %s will be rotated", currentState.ID.ValueString()))
+		for _, attr := range []string{"id", "certificate_type", "subject_cn", "serial_number", "sha1_thumbprint", "not_before", "not_after"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attr), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("installed"), types.BoolUnknown())...)
+		return
+	}
+	if plannedState.Install.ValueBool() && !currentState.Installed.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("installed"), types.BoolUnknown())...)
+	}
+}
+
+// Create a new resource.
+func (r *serverCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plannedState serverCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plannedState.Timeouts.Create(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plannedState.ServiceId.ValueString()
+	certificateId, operationId := r.upload(ctx, serviceId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// store the id right away, so a failed or timed out creation leaves a tainted resource behind instead of a leak
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), certificateId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, operationId, createTimeout, "Error uploading server certificate", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plannedState.Install.ValueBool() {
+		r.install(ctx, serviceId, certificateId, plannedState.Passphrase, createTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found := r.get(ctx, serviceId, certificateId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error creating server certificate",
+			fmt.Sprintf("Server certificate %s of broker service %s vanished", certificateId, serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *serverCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var currentState serverCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.get(ctx, currentState.ServiceId.ValueString(), currentState.ID.ValueString(), &currentState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Removing vanished resource from state gracefully")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &currentState)...)
+}
+
+// Update rotates or installs the certificate and sets the updated Terraform state on success.
+func (r *serverCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plannedState, currentState serverCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plannedState)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plannedState.Timeouts.Update(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := currentState.ServiceId.ValueString()
+	certificateId := currentState.ID.ValueString()
+	if certificateRotation(plannedState, currentState) {
+		// upload and install the new certificate before the old one is deleted, so the broker always has a certificate
+		newCertificateId, operationId := r.upload(ctx, serviceId, &plannedState, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Rotating server certificate %s to %s on broker %s", certificateId, newCertificateId, serviceId))
+		waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, operationId, updateTimeout, "Error uploading server certificate", &resp.Diagnostics)
+		if !resp.Diagnostics.HasError() && plannedState.Install.ValueBool() {
+			r.install(ctx, serviceId, newCertificateId, plannedState.Passphrase, updateTimeout, &resp.Diagnostics)
+		}
+		if resp.Diagnostics.HasError() {
+			// the state keeps the old certificate, remove the new one so a retry does not leave another copy behind
+			var cleanupDiags diag.Diagnostics
+			r.delete(ctx, serviceId, newCertificateId, updateTimeout, &cleanupDiags)
+			if cleanupDiags.HasError() {
+				resp.Diagnostics.AddWarning(
+					"Error deleting server certificate",
+					fmt.Sprintf("Could not delete the new server certificate %s of broker service %s after the failed rotation, please delete it manually: %s",
+						newCertificateId, serviceId, cleanupDiags.Errors()[0].Detail()),
+				)
+			}
+			return
+		}
+		r.delete(ctx, serviceId, certificateId, updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// the new certificate is in use, so the state has to point to it, the old one is left behind
+			var getDiags diag.Diagnostics
+			if r.get(ctx, serviceId, newCertificateId, &plannedState, &getDiags) {
+				resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+			} else {
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), newCertificateId)...)
+			}
+			resp.Diagnostics.AddError(
+				"Error rotating server certificate",
+				fmt.Sprintf("Server certificate %s of broker service %s has been replaced by %s, but could not be deleted, please delete it manually", certificateId, serviceId, newCertificateId),
+			)
+			return
+		}
+		certificateId = newCertificateId
+	} else if plannedState.Install.ValueBool() && !currentState.Installed.ValueBool() {
+		r.install(ctx, serviceId, certificateId, plannedState.Passphrase, updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	found := r.get(ctx, serviceId, certificateId, &plannedState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error updating server certificate",
+			fmt.Sprintf("Server certificate %s of broker service %s vanished", certificateId, serviceId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plannedState)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *serverCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var currentState serverCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := currentState.Timeouts.Delete(ctx, r.cMProviderData.PollingTimeoutDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.delete(ctx, currentState.ServiceId.ValueString(), currentState.ID.ValueString(), deleteTimeout, &resp.Diagnostics)
+}
+
+// ImportState imports a certificate using service_id/certificate_id.
+func (r *serverCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceId, certificateId, ok := strings.Cut(req.ID, "/")
+	if !ok || serviceId == "" || certificateId == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_id/certificate_id. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), certificateId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("install"), false)...)
+}
+
+// helper uploading the certificate of the model, returns the new certificate id and the operation id
+func (r *serverCertificateResource) upload(ctx context.Context, serviceId string, model *serverCertificateResourceModel, diagnostics *diag.Diagnostics) (string, string) {
+	body := missioncontrol.UploadCertificateRequest{
+		Certificate: model.Certificate.ValueString(),
+		PrivateKey:  model.PrivateKey.ValueString(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("Uploading server certificate to broker %s", serviceId))
+	uploadResp, err := r.cMProviderData.Client.UploadServerCertificateWithResponse(ctx, serviceId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error uploading server certificate",
+			"Could not upload server certificate, unexpected error: "+err.Error(),
+		)
+		return "", ""
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(uploadResp.Body)))
+	if uploadResp.StatusCode() != 202 {
+		diagnostics.AddError(
+			"Error uploading server certificate",
+			fmt.Sprintf("Unexpected response code: %v\n%s", uploadResp.StatusCode(), parseErrorResponse(uploadResp.Body)),
+		)
+		return "", ""
+	}
+	if uploadResp.JSON202 == nil || uploadResp.JSON202.Data.ResourceId == nil || uploadResp.JSON202.Data.Id == nil {
+		diagnostics.AddError(
+			"Error uploading server certificate",
+			fmt.Sprintf("Unexpected response without certificate or operation id:\n%s", uploadResp.Body),
+		)
+		return "", ""
+	}
+	return *(uploadResp.JSON202.Data.ResourceId), *(uploadResp.JSON202.Data.Id)
+}
+
+// helper installing a certificate and waiting for the operation
+func (r *serverCertificateResource) install(ctx context.Context, serviceId string, certificateId string, passphrase types.String, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	body := missioncontrol.InstallCertificateRequest{
+		Passphrase: passphrase.ValueStringPointer(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("Installing server certificate %s on broker %s", certificateId, serviceId))
+	installResp, err := r.cMProviderData.Client.InstallServerCertificateWithResponse(ctx, serviceId, certificateId, body, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error installing server certificate",
+			"Could not install server certificate, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(installResp.Body)))
+	if installResp.StatusCode() != 202 {
+		diagnostics.AddError(
+			"Error installing server certificate",
+			fmt.Sprintf("Unexpected response code: %v\n%s", installResp.StatusCode(), parseErrorResponse(installResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(installResp.JSON202.Data.Id), timeout, "Error installing server certificate", diagnostics)
+}
+
+// helper deleting a certificate and waiting for the operation, a missing certificate is tolerated
+func (r *serverCertificateResource) delete(ctx context.Context, serviceId string, certificateId string, timeout time.Duration, diagnostics *diag.Diagnostics) {
+	delResp, err := r.cMProviderData.Client.DeleteServerCertificateByIdWithResponse(ctx, serviceId, certificateId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error deleting server certificate",
+			"Could not delete server certificate, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(delResp.Body)))
+	if delResp.StatusCode() == 404 {
+		tflog.Warn(ctx, fmt.Sprintf("Could not find server certificate %s of broker service %s", certificateId, serviceId))
+		// this is tolerable!
+		return
+	}
+	if delResp.StatusCode() != 202 {
+		diagnostics.AddError(
+			"Error deleting server certificate",
+			fmt.Sprintf("Unexpected response code: %v\n%s", delResp.StatusCode(), parseErrorResponse(delResp.Body)),
+		)
+		return
+	}
+	waitForOperationSuccess(ctx, r.cMProviderData, r.cMProviderData.BearerReqEditorFn, serviceId, *(delResp.JSON202.Data.Id), timeout, "Error deleting server certificate", diagnostics)
+}
+
+// helper reading the certificate into the model, returns false if it does not exist
+func (r *serverCertificateResource) get(ctx context.Context, serviceId string, certificateId string, model *serverCertificateResourceModel, diagnostics *diag.Diagnostics) bool {
+	getResp, err := r.cMProviderData.Client.GetServerCertificateByIdWithResponse(ctx, serviceId, certificateId, r.cMProviderData.BearerReqEditorFn)
+	if err != nil {
+		diagnostics.AddError(
+			"Error getting server certificate",
+			"Could not get server certificate, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response Body:%s", redactedJSON(getResp.Body)))
+	if getResp.StatusCode() == 404 {
+		return false
+	}
+	if getResp.StatusCode() != 200 {
+		diagnostics.AddError(
+			"Error getting server certificate",
+			fmt.Sprintf("Unexpected response code: %v\n%s", getResp.StatusCode(), parseErrorResponse(getResp.Body)),
+		)
+		return false
+	}
+
+	certificate := getResp.JSON200.Data
+	model.ID = types.StringPointerValue(certificate.Id)
+	model.ServiceId = types.StringValue(serviceId)
+	installed := certificate.Installed != nil && *certificate.Installed
+	model.Installed = types.BoolValue(installed)
+	// install only reflects drift when it is wanted, false never uninstalls
+	if model.Install.ValueBool() {
+		model.Install = types.BoolValue(installed)
+	}
+	if certificate.CertificateType != nil {
+		model.CertificateType = types.StringValue(string(*certificate.CertificateType))
+	} else {
+		model.CertificateType = types.StringValue("")
+	}
+	model.SubjectCN = types.StringValue(stringValue(certificate.SubjectCN))
+	model.SerialNumber = types.StringValue(stringValue(certificate.SerialNumber))
+	model.Sha1Thumbprint = types.StringValue(stringValue(certificate.Sha1Thumbprint))
+	model.NotBefore = types.StringValue(stringValue(certificate.ValidityNotBefore))
+	model.NotAfter = types.StringValue(stringValue(certificate.ValidityNotAfter))
+	return true
+}
+
+// certificateRotation tells whether the planned certificate replaces the current one. After an import the
+// certificate material is not known, then the thumbprints of the configured and the uploaded certificate are compared
+func certificateRotation(plannedState serverCertificateResourceModel, currentState serverCertificateResourceModel) bool {
+	if currentState.Certificate.IsNull() {
+		thumbprint := certificateThumbprint(plannedState.Certificate.ValueString())
+		return thumbprint == "" || thumbprint != normalizeThumbprint(currentState.Sha1Thumbprint.ValueString())
+	}
+	return !plannedState.Certificate.Equal(currentState.Certificate) || !plannedState.PrivateKey.Equal(currentState.PrivateKey)
+}
+
+// certificateThumbprint returns the SHA1 thumbprint of the first certificate of a PEM, or "" if there is none
+func certificateThumbprint(pemText string) string {
+	rest := []byte(pemText)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return ""
+		}
+		if block.Type == "CERTIFICATE" {
+			return fmt.Sprintf("%X", sha1.Sum(block.Bytes))
+		}
+	}
+}
+
+// normalizeThumbprint removes separators and the case of a hex thumbprint like ab:cd:...
+func normalizeThumbprint(thumbprint string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", " ", "").Replace(thumbprint))
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccServerCertificateResource(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	cert1, key1 := testCertificatePEM(t, "broker1.example.com")
+	cert2, key2 := testCertificatePEM(t, "broker2.example.com")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			// Create and Read testing, upload only
+			{
+				Config: testServerCertificateConfig("c1", "ocs-prov-c1", cert1, key1, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_server_certificate.c1",
+						tfjsonpath.New("subject_cn"),
+						knownvalue.StringExact("broker1.example.com"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_server_certificate.c1",
+						tfjsonpath.New("installed"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// ImportState testing, the certificate material can't be imported
+			{
+				ResourceName:            "gsolaceclustermgr_server_certificate.c1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testServerCertificateImportId("gsolaceclustermgr_server_certificate.c1"),
+				ImportStateVerifyIgnore: []string{"certificate", "private_key", "timeouts"},
+			},
+			// Install in place
+			{
+				Config: testServerCertificateConfig("c1", "ocs-prov-c1", cert1, key1, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_server_certificate.c1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_server_certificate.c1",
+						tfjsonpath.New("installed"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Rotation in place
+			{
+				Config: testServerCertificateConfig("c1", "ocs-prov-c1", cert2, key2, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gsolaceclustermgr_server_certificate.c1", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("gsolaceclustermgr_server_certificate.c1", tfjsonpath.New("id")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_server_certificate.c1",
+						tfjsonpath.New("subject_cn"),
+						knownvalue.StringExact("broker2.example.com"),
+					),
+					statecheck.ExpectKnownValue(
+						"gsolaceclustermgr_server_certificate.c1",
+						tfjsonpath.New("installed"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccServerCertificateResourceFailedRotation(t *testing.T) {
+	if os.Getenv("FAKE_SERVER_EXT") == "" {
+		startFakeServer()
+		defer stopFakeServer()
+	}
+	cert1, key1 := testCertificatePEM(t, "broker1.example.com")
+	// the fakeserver can't install certificates for subjects starting with "fail"
+	cert2, key2 := testCertificatePEM(t, "fail.example.com")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBrokerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testServerCertificateConfig("c2", "ocs-prov-c2", cert1, key1, true),
+			},
+			// the failed rotation removes the new certificate again
+			{
+				Config:      testServerCertificateConfig("c2", "ocs-prov-c2", cert2, key2, true),
+				ExpectError: regexp.MustCompile("Error installing server certificate"),
+			},
+			// the state still points to the old certificate, which is the only one left
+			{
+				Config: testServerCertificateConfig("c2", "ocs-prov-c2", cert1, key1, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: testServerCertificateCount("gsolaceclustermgr_server_certificate.c2", 1),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestCertificateRotation(t *testing.T) {
+	cert1, key1 := testCertificatePEM(t, "a.example.com")
+	cert2, _ := testCertificatePEM(t, "b.example.com")
+	current := serverCertificateResourceModel{
+		Certificate:    types.StringValue(cert1),
+		PrivateKey:     types.StringValue(key1),
+		Sha1Thumbprint: types.StringValue(certificateThumbprint(cert1)),
+	}
+	imported := serverCertificateResourceModel{
+		Certificate:    types.StringNull(),
+		PrivateKey:     types.StringNull(),
+		Sha1Thumbprint: types.StringValue(strings.ToLower(certificateThumbprint(cert1))),
+	}
+	tests := []struct {
+		name    string
+		planned serverCertificateResourceModel
+		current serverCertificateResourceModel
+		want    bool
+	}{
+		{"unchanged", current, current, false},
+		{"changed certificate", serverCertificateResourceModel{Certificate: types.StringValue(cert2), PrivateKey: types.StringValue(key1)}, current, true},
+		{"changed key", serverCertificateResourceModel{Certificate: types.StringValue(cert1), PrivateKey: types.StringValue("other")}, current, true},
+		{"unknown certificate", serverCertificateResourceModel{Certificate: types.StringUnknown(), PrivateKey: types.StringValue(key1)}, current, true},
+		{"imported same", current, imported, false},
+		{"imported other", serverCertificateResourceModel{Certificate: types.StringValue(cert2), PrivateKey: types.StringValue(key1)}, imported, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := certificateRotation(tt.planned, tt.current); got != tt.want {
+				t.Errorf("certificateRotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertificateThumbprint(t *testing.T) {
+	cert, key := testCertificatePEM(t, "a.example.com")
+	block, _ := pem.Decode([]byte(cert))
+	if got := certificateThumbprint(key + cert); got != fmt.Sprintf("%X", sha1.Sum(block.Bytes)) {
+		t.Errorf("certificateThumbprint() = %v", got)
+	}
+	if got := certificateThumbprint("no pem"); got != "" {
+		t.Errorf("certificateThumbprint() = %v, want empty", got)
+	}
+	if got := normalizeThumbprint("ab:cd:0f"); got != "ABCD0F" {
+		t.Errorf("normalizeThumbprint() = %v", got)
+	}
+}
+
+func testServerCertificateCount(resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if svr == nil {
+			return nil
+		}
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+		if count := svr.ServerCertificateCount(rs.Primary.Attributes["service_id"]); count != expected {
+			return fmt.Errorf("expected %d server certificates, got %d", expected, count)
+		}
+		return nil
+	}
+}
+
+func testServerCertificateImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return rs.Primary.Attributes["service_id"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+// testCertificatePEM creates a self-signed certificate and its private key
+func testCertificatePEM(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+func testServerCertificateConfig(rname string, brokerName string, certificate string, privateKey string, install bool) string {
+	return providerConfig + fmt.Sprintf(`
+	resource "gsolaceclustermgr_broker" "%[1]s" {
+		serviceclass_id = "ENTERPRISE_250_STANDALONE"
+		name            = "%[2]s"
+		datacenter_id   = "aks-germanywestcentral"
+	}
+	resource "gsolaceclustermgr_server_certificate" "%[1]s" {
+		service_id  = gsolaceclustermgr_broker.%[1]s.id
+		certificate = <<-EOT
+%[3]s
+EOT
+		private_key = <<-EOT
+%[4]s
+EOT
+		install     = %[5]t
+	}
+	`, rname, brokerName, strings.TrimSpace(certificate), strings.TrimSpace(privateKey), install)
+}